@@ -1,12 +1,25 @@
 package client
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
+
+	"steampipe-plugin-ndo/container"
 )
 
+// DefaultTokenLifetime is used when neither the login response nor the token
+// itself says how long the token is valid for
+const DefaultTokenLifetime int64 = 1200
+
+// ND tokens this close to expiry (in seconds) are refreshed before use
+const tokenRefreshWindow int64 = 60
+
 type Auth struct {
 	Token  string
 	Expiry time.Time
@@ -19,6 +32,10 @@ func (au *Auth) IsValid() bool {
 	return false
 }
 
+func (au *Auth) NeedsRefresh() bool {
+	return au.Token != "" && au.Expiry.Unix()-time.Now().Unix() < tokenRefreshWindow
+}
+
 func (t *Auth) CalculateExpiry(willExpire int64) {
 	t.Expiry = time.Unix((time.Now().Unix() + willExpire), 0)
 }
@@ -27,11 +44,68 @@ func (t *Auth) estimateExpireTime() int64 {
 	return time.Now().Unix() + 3
 }
 
-func (client *Client) InjectAuthenticationHeader(req *http.Request, path string) (*http.Request, error) {
+// tokenLifetime returns how many seconds the token issued in a login or refresh
+// response stays valid. An explicit lifetime in the response wins, then the exp
+// claim of the token, then DefaultTokenLifetime.
+func tokenLifetime(obj *container.Container, token string) int64 {
+	for _, key := range []string{"expiresIn", "expires_in", "sessionTimeout"} {
+		if lifetime, ok := obj.S(key).Data().(float64); ok && lifetime > 0 {
+			return int64(lifetime)
+		}
+	}
+
+	if expiry := jwtExpiry(token); expiry > 0 {
+		if lifetime := expiry - time.Now().Unix(); lifetime > 0 {
+			return lifetime
+		}
+	}
+
+	log.Printf("[DEBUG] No token lifetime in response, assuming %d seconds", DefaultTokenLifetime)
+	return DefaultTokenLifetime
+}
+
+// jwtExpiry returns the exp claim of a JWT, or 0 if the token is not a JWT
+func jwtExpiry(token string) int64 {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return 0
+	}
+
+	claims, err := container.ParseJSON(payload)
+	if err != nil {
+		return 0
+	}
+
+	if expiry, ok := claims.S("exp").Data().(float64); ok {
+		return int64(expiry)
+	}
+	return 0
+}
+
+// InjectAuthenticationHeader sets the bearer token on req, logging in or refreshing
+// the token first when needed. ctx bounds the login, which runs under authMutex.
+func (client *Client) InjectAuthenticationHeader(ctx context.Context, req *http.Request, path string) (*http.Request, error) {
 	log.Printf("[DEBUG] Begin Injection")
+	// hydrate calls share the client, so only one of them may log in at a time
+	client.authMutex.Lock()
+	defer client.authMutex.Unlock()
+
+	if client.platform == "nd" && client.AuthToken != nil && client.AuthToken.IsValid() && client.AuthToken.NeedsRefresh() {
+		err := client.Refresh(ctx)
+		if err != nil {
+			log.Printf("[DEBUG] Token refresh failed, logging in again: %v", err)
+			client.AuthToken.Token = ""
+		}
+	}
+
 	if client.AuthToken == nil || !client.AuthToken.IsValid() {
 
-		err := client.Authenticate()
+		err := client.Authenticate(ctx)
 
 		if err != nil {
 			return nil, err
@@ -43,3 +117,51 @@ func (client *Client) InjectAuthenticationHeader(req *http.Request, path string)
 
 	return req, nil
 }
+
+// Refresh exchanges the current ND token for a new one without sending the
+// credentials again. The caller must hold authMutex.
+func (c *Client) Refresh(ctx context.Context) error {
+	req, err := c.MakeRestRequestWithContext(ctx, "POST", "/refresh", container.New(), false)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AuthToken.Token))
+
+	obj, resp, err := c.do(req, false)
+	if err != nil {
+		return err
+	}
+
+	if obj == nil || resp == nil || resp.StatusCode != http.StatusOK {
+		return errors.New("Token refresh rejected")
+	}
+
+	token := StripQuotes(obj.S("token").String())
+	if token == "" || token == "{}" {
+		token = StripQuotes(obj.S("jwttoken").String())
+	}
+	if token == "" || token == "{}" {
+		return errors.New("Token refresh returned no token")
+	}
+
+	c.AuthToken.Token = token
+	c.AuthToken.CalculateExpiry(tokenLifetime(obj, token))
+	return nil
+}
+
+// reauthenticate logs in again unless another caller already replaced staleToken,
+// and returns the token to use
+func (c *Client) reauthenticate(ctx context.Context, staleToken string) (string, error) {
+	c.authMutex.Lock()
+	defer c.authMutex.Unlock()
+
+	if c.AuthToken != nil && c.AuthToken.Token != staleToken && c.AuthToken.IsValid() {
+		return c.AuthToken.Token, nil
+	}
+
+	err := c.Authenticate(ctx)
+	if err != nil {
+		return "", err
+	}
+	return c.AuthToken.Token, nil
+}
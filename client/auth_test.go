@@ -0,0 +1,160 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"steampipe-plugin-ndo/container"
+)
+
+// testJWT returns an unsigned JWT carrying the given claims
+func testJWT(claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+	return header + "." + payload + ".signature"
+}
+
+func TestJwtExpiry(t *testing.T) {
+	cases := []struct {
+		name  string
+		token string
+		want  int64
+	}{
+		{name: "exp claim", token: testJWT(`{"exp":1700000000}`), want: 1700000000},
+		{name: "padded payload", token: "e30." + base64.URLEncoding.EncodeToString([]byte(`{"exp": 1700000000}`)) + ".signature", want: 1700000000},
+		{name: "no exp claim", token: testJWT(`{"sub":"admin"}`), want: 0},
+		{name: "exp not a number", token: testJWT(`{"exp":"tomorrow"}`), want: 0},
+		{name: "payload not json", token: testJWT(`not json`), want: 0},
+		{name: "payload not base64", token: "header.!!!.signature", want: 0},
+		{name: "opaque token", token: "5b2f9c1e", want: 0},
+		{name: "empty", token: "", want: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := jwtExpiry(tc.token); got != tc.want {
+				t.Errorf("jwtExpiry(%q) = %d, want %d", tc.token, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTokenLifetime(t *testing.T) {
+	jwt := testJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Unix()+500))
+	expiredJWT := testJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Unix()-500))
+
+	cases := []struct {
+		name     string
+		response string
+		token    string
+		min      int64
+		max      int64
+	}{
+		{name: "expiresIn", response: `{"expiresIn": 600}`, token: jwt, min: 600, max: 600},
+		{name: "expires_in", response: `{"expires_in": 300}`, token: jwt, min: 300, max: 300},
+		{name: "sessionTimeout", response: `{"sessionTimeout": 900}`, token: "opaque", min: 900, max: 900},
+		{name: "zero response lifetime falls back to jwt", response: `{"expiresIn": 0}`, token: jwt, min: 495, max: 500},
+		{name: "jwt exp", response: `{}`, token: jwt, min: 495, max: 500},
+		{name: "expired jwt falls back to default", response: `{}`, token: expiredJWT, min: DefaultTokenLifetime, max: DefaultTokenLifetime},
+		{name: "opaque token falls back to default", response: `{}`, token: "opaque", min: DefaultTokenLifetime, max: DefaultTokenLifetime},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj, err := container.ParseJSON([]byte(tc.response))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tokenLifetime(obj, tc.token); got < tc.min || got > tc.max {
+				t.Errorf("tokenLifetime(%s) = %d, want between %d and %d", tc.response, got, tc.min, tc.max)
+			}
+		})
+	}
+}
+
+func TestIsTokenRejected(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		want   bool
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, body: "", want: true},
+		{name: "forbidden token expired", status: http.StatusForbidden, body: `{"message": "Token expired"}`, want: true},
+		{name: "forbidden invalid token", status: http.StatusForbidden, body: `{"message": "Invalid token"}`, want: true},
+		{name: "forbidden permission", status: http.StatusForbidden, body: `{"message": "User does not have permission"}`, want: false},
+		{name: "forbidden token without reason", status: http.StatusForbidden, body: `{"message": "token required for tenant"}`, want: false},
+		{name: "other status", status: http.StatusInternalServerError, body: `{"message": "Token expired"}`, want: false},
+		{name: "ok", status: http.StatusOK, body: `{}`, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isTokenRejected(&http.Response{StatusCode: tc.status}, tc.body); got != tc.want {
+				t.Errorf("isTokenRejected(%d, %q) = %v, want %v", tc.status, tc.body, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReauthenticateOnRejectedToken(t *testing.T) {
+	cases := []struct {
+		name       string
+		rejections int32
+		wantErr    bool
+		wantLogins int32
+		wantGets   int32
+	}{
+		{name: "replayed once with a new token", rejections: 1, wantErr: false, wantLogins: 2, wantGets: 2},
+		{name: "second rejection is not replayed", rejections: 10, wantErr: true, wantLogins: 2, wantGets: 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var logins, gets int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v1/auth/login":
+					fmt.Fprintf(w, `{"token": "token-%d"}`, atomic.AddInt32(&logins, 1))
+				case "/api/v1/schemas":
+					if atomic.AddInt32(&gets, 1) <= tc.rejections {
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+					if got, want := r.Header.Get("Authorization"), "Bearer token-2"; got != want {
+						t.Errorf("replayed request Authorization = %q, want %q", got, want)
+					}
+					w.Write([]byte(`{"schemas": []}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			c := initClient(srv.URL, "admin", Password("secret"), BackoffMinDelay(0))
+			req, err := c.MakeRestRequest("GET", "/api/v1/schemas", nil, true)
+			if err != nil {
+				t.Fatalf("MakeRestRequest returned error: %v", err)
+			}
+
+			_, _, err = c.Do(req)
+			if tc.wantErr && err == nil {
+				t.Errorf("Do returned no error, want one")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Do returned error: %v", err)
+			}
+			if got := atomic.LoadInt32(&logins); got != tc.wantLogins {
+				t.Errorf("logged in %d times, want %d", got, tc.wantLogins)
+			}
+			if got := atomic.LoadInt32(&gets); got != tc.wantGets {
+				t.Errorf("sent GET %d times, want %d", got, tc.wantGets)
+			}
+		})
+	}
+}
@@ -2,15 +2,18 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"steampipe-plugin-ndo/container"
@@ -33,6 +36,7 @@ const DefaultReqTimeoutVal int = 100
 const DefaultBackoffMinDelay int = 4
 const DefaultBackoffMaxDelay int = 60
 const DefaultBackoffDelayFactor float64 = 3
+const DefaultMaxRetries int = 3
 const DefaultAPIURL = "/mso/api/v1/"
 
 // Client is the main entry point
@@ -50,11 +54,22 @@ type Client struct {
 	reqTimeoutSet      bool
 	reqTimeoutVal      uint32
 	skipLoggingPayload bool
+	maxRetries         int
+	backoffMinDelay    int
+	backoffMaxDelay    int
+	backoffDelayFactor float64
+	authMutex          sync.Mutex
+	jitter             *rand.Rand
+	jitterMutex        sync.Mutex
 	*ServiceManager
 }
 
-// singleton implementation of a client
-var ndoClientImpl *Client
+// registry of initialized clients keyed by connection identity, so several
+// connections can share the plugin process without clobbering each other's token
+var (
+	clientRegistry      = map[string]*Client{}
+	clientRegistryMutex sync.Mutex
+)
 
 type Option func(*Client)
 
@@ -88,17 +103,46 @@ func Platform(platform string) Option {
 	}
 }
 
+func MaxRetries(maxRetries int) Option {
+	return func(client *Client) {
+		client.maxRetries = maxRetries
+	}
+}
+
+func BackoffMinDelay(minDelay int) Option {
+	return func(client *Client) {
+		client.backoffMinDelay = minDelay
+	}
+}
+
+func BackoffMaxDelay(maxDelay int) Option {
+	return func(client *Client) {
+		client.backoffMaxDelay = maxDelay
+	}
+}
+
+func BackoffDelayFactor(factor float64) Option {
+	return func(client *Client) {
+		client.backoffDelayFactor = factor
+	}
+}
+
 func initClient(clientUrl, username string, options ...Option) *Client {
 	var transport *http.Transport
 	bUrl, err := url.Parse(clientUrl)
 	if err != nil {
 		// cannot move forward if url is undefined
-		log.Fatal("Client URL not defined: %s", err)
+		log.Fatalf("Client URL not defined: %s", err)
 	}
 	client := &Client{
-		BaseURL:  bUrl,
-		username: username,
-		APIURL:   DefaultAPIURL,
+		BaseURL:            bUrl,
+		username:           username,
+		APIURL:             DefaultAPIURL,
+		maxRetries:         DefaultMaxRetries,
+		backoffMinDelay:    DefaultBackoffMinDelay,
+		backoffMaxDelay:    DefaultBackoffMaxDelay,
+		backoffDelayFactor: DefaultBackoffDelayFactor,
+		jitter:             rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 
 	for _, option := range options {
@@ -127,25 +171,51 @@ func initClient(clientUrl, username string, options ...Option) *Client {
 	return client
 }
 
-// GetClient returns a singleton
+// GetClient returns the client registered for the given URI and options,
+// initializing a new one on first use
 func GetClient(clientUrl, username string, options ...Option) *Client {
-	if ndoClientImpl == nil {
-		log.Printf("[DEBUG] Initializing new client...")
-		ndoClientImpl = initClient(clientUrl, username, options...)
-	} else {
-		log.Printf("[DEBUG] Verifying cached client...")
-		// making sure it is the same client
-		bUrl, err := url.Parse(clientUrl)
-		if err != nil {
-			// cannot move forward if url is undefined
-			log.Fatal("URL undefined: %s", err)
-		}
-		if bUrl != ndoClientImpl.BaseURL {
-			log.Printf("[DEBUG] Cached client miss...")
-			ndoClientImpl = initClient(clientUrl, username, options...)
-		}
+	probe := &Client{
+		username:           username,
+		maxRetries:         DefaultMaxRetries,
+		backoffMinDelay:    DefaultBackoffMinDelay,
+		backoffMaxDelay:    DefaultBackoffMaxDelay,
+		backoffDelayFactor: DefaultBackoffDelayFactor,
 	}
-	return ndoClientImpl
+	for _, option := range options {
+		option(probe)
+	}
+	// every option is part of the key, so connections that differ in any of
+	// them get a client of their own instead of re-initializing a shared one
+	key := clientKey(clientUrl, probe)
+
+	clientRegistryMutex.Lock()
+	defer clientRegistryMutex.Unlock()
+
+	if cached, ok := clientRegistry[key]; ok {
+		log.Printf("[DEBUG] Using cached client...")
+		return cached
+	}
+
+	log.Printf("[DEBUG] Initializing new client...")
+	client := initClient(clientUrl, username, options...)
+	clientRegistry[key] = client
+	return client
+}
+
+func clientKey(clientUrl string, probe *Client) string {
+	return strings.Join([]string{
+		clientUrl,
+		probe.username,
+		probe.password,
+		probe.domain,
+		probe.platform,
+		probe.proxyUrl,
+		strconv.FormatBool(probe.insecure),
+		strconv.Itoa(probe.maxRetries),
+		strconv.Itoa(probe.backoffMinDelay),
+		strconv.Itoa(probe.backoffMaxDelay),
+		strconv.FormatFloat(probe.backoffDelayFactor, 'g', -1, 64),
+	}, "|")
 }
 
 func (c *Client) configProxy(transport *http.Transport) *http.Transport {
@@ -178,7 +248,12 @@ func (c *Client) useInsecureHTTPClient(insecure bool) *http.Transport {
 }
 
 func (c *Client) MakeRestRequest(method string, path string, body *container.Container, authenticated bool) (*http.Request, error) {
-	if c.platform == "nd" && path != "/login" {
+	return c.MakeRestRequestWithContext(context.Background(), method, path, body, authenticated)
+}
+
+// MakeRestRequestWithContext builds a request that is aborted when ctx is cancelled
+func (c *Client) MakeRestRequestWithContext(ctx context.Context, method string, path string, body *container.Container, authenticated bool) (*http.Request, error) {
+	if c.platform == "nd" && path != "/login" && path != "/refresh" {
 		if strings.HasPrefix(path, "/") {
 			path = path[1:]
 		}
@@ -192,9 +267,9 @@ func (c *Client) MakeRestRequest(method string, path string, body *container.Con
 	fURL := c.BaseURL.ResolveReference(url)
 	var req *http.Request
 	if method == "GET" || method == "DELETE" {
-		req, err = http.NewRequest(method, fURL.String(), nil)
+		req, err = http.NewRequestWithContext(ctx, method, fURL.String(), nil)
 	} else {
-		req, err = http.NewRequest(method, fURL.String(), bytes.NewBuffer((body.Bytes())))
+		req, err = http.NewRequestWithContext(ctx, method, fURL.String(), bytes.NewBuffer((body.Bytes())))
 	}
 	if err != nil {
 		return nil, err
@@ -203,7 +278,7 @@ func (c *Client) MakeRestRequest(method string, path string, body *container.Con
 	log.Printf("HTTP request %s %s", method, path)
 
 	if authenticated {
-		req, err = c.InjectAuthenticationHeader(req, path)
+		req, err = c.InjectAuthenticationHeader(ctx, req, path)
 		if err != nil {
 			return req, err
 		}
@@ -213,8 +288,9 @@ func (c *Client) MakeRestRequest(method string, path string, body *container.Con
 	return req, nil
 }
 
-// Authenticate is used to
-func (c *Client) Authenticate() error {
+// Authenticate logs in and stores the issued token. The login is aborted when
+// ctx is cancelled.
+func (c *Client) Authenticate(ctx context.Context) error {
 	method := "POST"
 	path := "/api/v1/auth/login"
 	var authPayload string
@@ -237,7 +313,7 @@ func (c *Client) Authenticate() error {
 		if c.platform == "nd" {
 			body.Set(c.domain, "domain")
 		} else {
-			domainId, err := c.GetDomainId(c.domain)
+			domainId, err := c.GetDomainId(ctx, c.domain)
 			if err != nil {
 				return err
 			}
@@ -245,12 +321,12 @@ func (c *Client) Authenticate() error {
 		}
 	}
 
-	req, err := c.MakeRestRequest(method, path, body, false)
+	req, err := c.MakeRestRequestWithContext(ctx, method, path, body, false)
 	if err != nil {
 		return err
 	}
 
-	obj, _, err := c.Do(req)
+	obj, _, err := c.DoWithContext(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -270,18 +346,18 @@ func (c *Client) Authenticate() error {
 		c.AuthToken = &Auth{}
 	}
 	c.AuthToken.Token = stripQuotes(token)
-	c.AuthToken.CalculateExpiry(1200) //refreshTime=1200 Sec
+	c.AuthToken.CalculateExpiry(tokenLifetime(obj, c.AuthToken.Token))
 
 	return nil
 }
 
-func (c *Client) GetDomainId(domain string) (string, error) {
-	req, err := c.MakeRestRequest("GET", "/api/v1/auth/login-domains", nil, false)
+func (c *Client) GetDomainId(ctx context.Context, domain string) (string, error) {
+	req, err := c.MakeRestRequestWithContext(ctx, "GET", "/api/v1/auth/login-domains", nil, false)
 	if err != nil {
 		return "", err
 	}
 
-	obj, _, err := c.Do(req)
+	obj, _, err := c.DoWithContext(ctx, req)
 
 	if err != nil {
 		return "", err
@@ -314,8 +390,20 @@ func StrtoInt(s string, startIndex int, bitSize int) (int64, error) {
 }
 
 func (c *Client) Do(req *http.Request) (*container.Container, *http.Response, error) {
+	return c.do(req, true)
+}
+
+// DoWithContext sends the request bound to ctx, so cancelling ctx aborts the
+// request as well as any retry or re-authentication still pending
+func (c *Client) DoWithContext(ctx context.Context, req *http.Request) (*container.Container, *http.Response, error) {
+	return c.do(req.WithContext(ctx), true)
+}
+
+// do sends the request and parses the response. When allowReauth is set, a request
+// rejected because its token expired is replayed once with a freshly issued token.
+func (c *Client) do(req *http.Request, allowReauth bool) (*container.Container, *http.Response, error) {
 	log.Printf("[DEBUG] Begining DO method %s", req.URL.String())
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -326,6 +414,23 @@ func (c *Client) Do(req *http.Request) (*container.Container, *http.Response, er
 	bodyStr := string(bodyBytes)
 	resp.Body.Close()
 	log.Printf("\nHTTP response unique string %s %s %s", req.Method, req.URL.String(), bodyStr)
+
+	staleToken := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if allowReauth && staleToken != "" && isTokenRejected(resp, bodyStr) {
+		log.Printf("[DEBUG] Token rejected for %s %s, authenticating again", req.Method, req.URL.String())
+		replay, err := c.replayRequest(req, staleToken)
+		if err != nil {
+			return nil, resp, err
+		}
+		return c.do(replay, false)
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := newAPIError(resp, bodyBytes)
+		log.Printf("[DEBUG] %s %s failed: %v", req.Method, req.URL.String(), apiErr)
+		return nil, resp, apiErr
+	}
+
 	if req.Method != "DELETE" && resp.StatusCode != 204 {
 		obj, err := container.ParseJSON(bodyBytes)
 
@@ -342,6 +447,35 @@ func (c *Client) Do(req *http.Request) (*container.Container, *http.Response, er
 	}
 }
 
+// isTokenRejected reports whether the response means the bearer token is no longer
+// accepted, as opposed to the user lacking permission on the object
+func isTokenRejected(resp *http.Response, body string) bool {
+	if resp.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	body = strings.ToLower(body)
+	return resp.StatusCode == http.StatusForbidden && strings.Contains(body, "token") &&
+		(strings.Contains(body, "expired") || strings.Contains(body, "invalid"))
+}
+
+// replayRequest re-authenticates and returns a copy of req carrying the new token
+func (c *Client) replayRequest(req *http.Request, staleToken string) (*http.Request, error) {
+	token, err := c.reauthenticate(req.Context(), staleToken)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := req.Clone(req.Context())
+	if req.GetBody != nil {
+		replay.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	replay.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return replay, nil
+}
+
 func stripQuotes(word string) string {
 	if strings.HasPrefix(word, "\"") && strings.HasSuffix(word, "\"") {
 		return strings.TrimSuffix(strings.TrimPrefix(word, "\""), "\"")
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"steampipe-plugin-ndo/container"
+)
+
+// APIError is returned when NDO or ND rejects a request, either with an HTTP
+// error status or with an error envelope in the response body
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Path       string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Code != "" {
+		msg += fmt.Sprintf(" (code %s)", e.Code)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// IsNotFound reports whether err is an APIError for an object that does not exist
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// newAPIError builds an APIError for a response with an HTTP error status, using
+// the error envelope in the body when there is one
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Path:       resp.Request.URL.Path,
+	}
+
+	if cont, err := container.ParseJSON(body); err == nil {
+		apiErr.Code, apiErr.Message, _ = parseErrorEnvelope(cont)
+	} else if text := strings.TrimSpace(string(body)); text != "" && !strings.HasPrefix(text, "<") {
+		apiErr.Message = text
+	}
+	return apiErr
+}
+
+// parseErrorEnvelope extracts the code and message of an error body. MSO answers
+// with {"code": .., "message": ..}, ND with {"code": .., "messages": [{"severity": .., "message": ..}]}
+// and some endpoints with {"errors": [..]} or {"error": ..}.
+func parseErrorEnvelope(cont *container.Container) (string, string, bool) {
+	code := valueString(cont.S("code"))
+
+	if message := valueString(cont.S("message")); message != "" {
+		return code, message, true
+	}
+
+	if messages, err := cont.S("messages").Children(); err == nil {
+		texts := []string{}
+		for _, message := range messages {
+			severity := strings.ToUpper(valueString(message.S("severity")))
+			if severity != "" && severity != "ERROR" {
+				continue
+			}
+			if code == "" {
+				code = valueString(message.S("code"))
+			}
+			texts = append(texts, valueString(message.S("message")))
+		}
+		if len(texts) > 0 {
+			return code, strings.Join(texts, "; "), true
+		}
+	}
+
+	if errs, err := cont.S("errors").Children(); err == nil && len(errs) > 0 {
+		texts := []string{}
+		for _, e := range errs {
+			if message := valueString(e.S("message")); message != "" {
+				texts = append(texts, message)
+			} else {
+				texts = append(texts, valueString(e))
+			}
+		}
+		return code, strings.Join(texts, "; "), true
+	}
+
+	if message := valueString(cont.S("error")); message != "" {
+		return code, message, true
+	}
+
+	return code, "", false
+}
+
+// valueString returns a scalar JSON value as a plain string, or "" if it is absent
+func valueString(cont *container.Container) string {
+	if cont == nil || cont.Data() == nil {
+		return ""
+	}
+	return StripQuotes(cont.String())
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"steampipe-plugin-ndo/container"
+)
+
+func TestParseErrorEnvelope(t *testing.T) {
+	cases := []struct {
+		name        string
+		body        string
+		wantCode    string
+		wantMessage string
+		wantError   bool
+	}{
+		{
+			name:        "mso envelope",
+			body:        `{"code": 400, "message": "Bad Request: invalid schema"}`,
+			wantCode:    "400",
+			wantMessage: "Bad Request: invalid schema",
+			wantError:   true,
+		},
+		{
+			name:        "mso envelope with string code",
+			body:        `{"code": "404", "message": "Schema not found"}`,
+			wantCode:    "404",
+			wantMessage: "Schema not found",
+			wantError:   true,
+		},
+		{
+			name:        "nd envelope keeps errors only",
+			body:        `{"code": 403, "messages": [{"severity": "ERROR", "message": "denied"}, {"severity": "WARNING", "message": "ignored"}, {"severity": "error", "message": "no access to tenant"}]}`,
+			wantCode:    "403",
+			wantMessage: "denied; no access to tenant",
+			wantError:   true,
+		},
+		{
+			name:        "nd envelope code from message",
+			body:        `{"messages": [{"message": "missing field"}, {"code": "E400", "severity": "ERROR", "message": "bad value"}]}`,
+			wantCode:    "E400",
+			wantMessage: "missing field; bad value",
+			wantError:   true,
+		},
+		{
+			name:      "nd envelope with warnings only",
+			body:      `{"messages": [{"severity": "WARNING", "message": "deprecated"}]}`,
+			wantError: false,
+		},
+		{
+			name:        "errors list",
+			body:        `{"errors": [{"message": "first"}, "second"]}`,
+			wantMessage: "first; second",
+			wantError:   true,
+		},
+		{
+			name:        "error field",
+			body:        `{"error": "unauthorized"}`,
+			wantMessage: "unauthorized",
+			wantError:   true,
+		},
+		{
+			name:      "empty errors list",
+			body:      `{"errors": []}`,
+			wantError: false,
+		},
+		{
+			name:      "code without message",
+			body:      `{"code": 404}`,
+			wantCode:  "404",
+			wantError: false,
+		},
+		{
+			name:      "regular body",
+			body:      `{"schemas": []}`,
+			wantError: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cont, err := container.ParseJSON([]byte(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			code, message, isError := parseErrorEnvelope(cont)
+			if code != tc.wantCode || message != tc.wantMessage || isError != tc.wantError {
+				t.Errorf("parseErrorEnvelope(%s) = (%q, %q, %v), want (%q, %q, %v)", tc.body, code, message, isError, tc.wantCode, tc.wantMessage, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	cases := []struct {
+		name        string
+		status      int
+		body        string
+		wantCode    string
+		wantMessage string
+	}{
+		{name: "json envelope", status: http.StatusBadRequest, body: `{"code": 400, "message": "invalid schema"}`, wantCode: "400", wantMessage: "invalid schema"},
+		{name: "html body", status: http.StatusBadGateway, body: "<html><body><h1>502 Bad Gateway</h1></body></html>"},
+		{name: "plain text body", status: http.StatusServiceUnavailable, body: " upstream unavailable \n", wantMessage: "upstream unavailable"},
+		{name: "empty body", status: http.StatusNotFound, body: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tc.status,
+				Request:    &http.Request{URL: &url.URL{Path: "/mso/api/v1/schemas/1"}},
+			}
+			apiErr := newAPIError(resp, []byte(tc.body))
+			if apiErr.StatusCode != tc.status || apiErr.Code != tc.wantCode || apiErr.Message != tc.wantMessage {
+				t.Errorf("newAPIError(%d, %q) = %+v, want status %d, code %q, message %q", tc.status, tc.body, apiErr, tc.status, tc.wantCode, tc.wantMessage)
+			}
+			if apiErr.Path != "/mso/api/v1/schemas/1" {
+				t.Errorf("newAPIError path = %q, want %q", apiErr.Path, "/mso/api/v1/schemas/1")
+			}
+		})
+	}
+}
+
+func TestCheckForErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		body        string
+		wantErr     bool
+		wantStatus  int
+		wantMessage string
+	}{
+		{name: "regular body", body: `{"schemas": []}`, wantErr: false},
+		{name: "informational message", body: `{"message": "Schema updated"}`, wantErr: false},
+		{name: "message with success code", body: `{"code": 200, "message": "OK"}`, wantErr: false},
+		{name: "message with error code", body: `{"code": 400, "message": "invalid schema"}`, wantErr: true, wantStatus: 400, wantMessage: "invalid schema"},
+		{name: "message with not found code", body: `{"code": "404", "message": "Schema not found"}`, wantErr: true, wantStatus: 404, wantMessage: "Schema not found"},
+		{name: "nd messages without code", body: `{"messages": [{"severity": "ERROR", "message": "bad value"}]}`, wantErr: true, wantStatus: 500, wantMessage: "bad value"},
+		{name: "nd messages with code", body: `{"code": 409, "messages": [{"severity": "ERROR", "message": "conflict"}]}`, wantErr: true, wantStatus: 409, wantMessage: "conflict"},
+		{name: "errors list", body: `{"errors": ["first"]}`, wantErr: true, wantStatus: 500, wantMessage: "first"},
+		{name: "error field", body: `{"error": "unauthorized"}`, wantErr: true, wantStatus: 500, wantMessage: "unauthorized"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cont, err := container.ParseJSON([]byte(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = CheckForErrors(cont, "GET", true)
+			if !tc.wantErr {
+				if err != nil {
+					t.Errorf("CheckForErrors(%s) = %v, want nil", tc.body, err)
+				}
+				return
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("CheckForErrors(%s) = %v, want an APIError", tc.body, err)
+			}
+			if apiErr.StatusCode != tc.wantStatus || apiErr.Message != tc.wantMessage {
+				t.Errorf("CheckForErrors(%s) = %+v, want status %d, message %q", tc.body, apiErr, tc.wantStatus, tc.wantMessage)
+			}
+		})
+	}
+
+	if err := CheckForErrors(nil, "GET", true); err != nil {
+		t.Errorf("CheckForErrors(nil) = %v, want nil", err)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "not found", err: &APIError{StatusCode: http.StatusNotFound}, want: true},
+		{name: "wrapped not found", err: fmt.Errorf("Error getting schema: %w", &APIError{StatusCode: http.StatusNotFound}), want: true},
+		{name: "other status", err: &APIError{StatusCode: http.StatusForbidden}, want: false},
+		{name: "other error", err: errors.New("connection refused"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsNotFound(tc.err); got != tc.want {
+				t.Errorf("IsNotFound(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAPIErrorString(t *testing.T) {
+	err := &APIError{StatusCode: http.StatusBadRequest, Code: "400", Message: "invalid schema"}
+	if got, want := err.Error(), "400 Bad Request (code 400): invalid schema"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
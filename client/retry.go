@@ -0,0 +1,99 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// doWithRetry sends the request, retrying idempotent requests that failed with
+// a transient error using a jittered exponential backoff
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+		// a cancelled query or an expired query deadline is final
+		if req.Context().Err() != nil {
+			return resp, err
+		}
+		if attempt >= c.maxRetries || !isIdempotent(req.Method) || !isRetryable(resp, err) {
+			return resp, err
+		}
+
+		delay := c.backoffDelay(attempt, resp)
+		if err != nil {
+			log.Printf("[WARN] %s %s failed: %v, retrying in %v (attempt %d of %d)", req.Method, req.URL.String(), err, delay, attempt+1, c.maxRetries)
+		} else {
+			log.Printf("[WARN] %s %s returned %s, retrying in %v (attempt %d of %d)", req.Method, req.URL.String(), resp.Status, delay, attempt+1, c.maxRetries)
+			// drain the body so the connection can be reused
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func isIdempotent(method string) bool {
+	return method == "GET" || method == "HEAD"
+}
+
+// isRetryable reports whether a response or transport error is worth another attempt:
+// connection resets, client timeouts (NGINX in ND gives up after 90 seconds),
+// throttling and gateway errors
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return true
+		}
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoffDelay returns backoffMinDelay * backoffDelayFactor^attempt seconds capped at
+// backoffMaxDelay, with up to half of it randomized. A Retry-After header sent with
+// the response takes precedence when it asks for a longer wait, within the same cap.
+func (c *Client) backoffDelay(attempt int, resp *http.Response) time.Duration {
+	seconds := float64(c.backoffMinDelay) * math.Pow(c.backoffDelayFactor, float64(attempt))
+	if seconds > float64(c.backoffMaxDelay) {
+		seconds = float64(c.backoffMaxDelay)
+	}
+	delay := time.Duration(seconds * float64(time.Second))
+	if delay > 0 {
+		// rand.Rand is not safe for concurrent use and hydrate calls share the client
+		c.jitterMutex.Lock()
+		delay = delay/2 + time.Duration(c.jitter.Int63n(int64(delay/2)+1))
+		c.jitterMutex.Unlock()
+	}
+
+	if resp != nil {
+		if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			if retryAfter > c.backoffMaxDelay {
+				retryAfter = c.backoffMaxDelay
+			}
+			if wait := time.Duration(retryAfter) * time.Second; wait > delay {
+				delay = wait
+			}
+		}
+	}
+	return delay
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		err    error
+		want   bool
+	}{
+		{name: "ok", status: http.StatusOK, want: false},
+		{name: "not found", status: http.StatusNotFound, want: false},
+		{name: "internal server error", status: http.StatusInternalServerError, want: false},
+		{name: "too many requests", status: http.StatusTooManyRequests, want: true},
+		{name: "bad gateway", status: http.StatusBadGateway, want: true},
+		{name: "service unavailable", status: http.StatusServiceUnavailable, want: true},
+		{name: "gateway timeout", status: http.StatusGatewayTimeout, want: true},
+		{name: "connection reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET), want: true},
+		{name: "eof", err: io.EOF, want: true},
+		{name: "unexpected eof", err: io.ErrUnexpectedEOF, want: true},
+		{name: "timeout", err: fmt.Errorf("dial: %w", timeoutError{}), want: true},
+		{name: "other error", err: errors.New("certificate signed by unknown authority"), want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var resp *http.Response
+			if tc.err == nil {
+				resp = &http.Response{StatusCode: tc.status}
+			}
+			if got := isRetryable(resp, tc.err); got != tc.want {
+				t.Errorf("isRetryable(%d, %v) = %v, want %v", tc.status, tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	c := initClient("https://nd.example.com", "admin", BackoffMinDelay(4), BackoffMaxDelay(60), BackoffDelayFactor(3))
+
+	cases := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		min        time.Duration
+		max        time.Duration
+	}{
+		{name: "first attempt", attempt: 0, min: 2 * time.Second, max: 4 * time.Second},
+		{name: "second attempt", attempt: 1, min: 6 * time.Second, max: 12 * time.Second},
+		{name: "capped at max delay", attempt: 3, min: 30 * time.Second, max: 60 * time.Second},
+		{name: "longer retry-after wins", attempt: 0, retryAfter: "10", min: 10 * time.Second, max: 10 * time.Second},
+		{name: "shorter retry-after ignored", attempt: 1, retryAfter: "1", min: 6 * time.Second, max: 12 * time.Second},
+		{name: "retry-after capped at max delay", attempt: 0, retryAfter: "3600", min: 60 * time.Second, max: 60 * time.Second},
+		{name: "invalid retry-after ignored", attempt: 0, retryAfter: "soon", min: 2 * time.Second, max: 4 * time.Second},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tc.retryAfter != "" {
+				resp.Header.Set("Retry-After", tc.retryAfter)
+			}
+			// the jitter is random, so sample it a few times
+			for i := 0; i < 50; i++ {
+				if got := c.backoffDelay(tc.attempt, resp); got < tc.min || got > tc.max {
+					t.Fatalf("backoffDelay(%d) = %v, want between %v and %v", tc.attempt, got, tc.min, tc.max)
+				}
+			}
+		})
+	}
+
+	zero := initClient("https://nd.example.com", "admin", BackoffMinDelay(0))
+	if got := zero.backoffDelay(2, nil); got != 0 {
+		t.Errorf("backoffDelay with no minimum delay = %v, want 0", got)
+	}
+}
+
+// newFlakyServer answers the first failures requests with 503, then 200
+func newFlakyServer(failures int32) (*httptest.Server, *int32) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	return srv, &hits
+}
+
+func TestDoWithRetry(t *testing.T) {
+	cases := []struct {
+		name       string
+		method     string
+		failures   int32
+		maxRetries int
+		wantStatus int
+		wantHits   int32
+	}{
+		{name: "get succeeds after 503", method: "GET", failures: 1, maxRetries: 3, wantStatus: http.StatusOK, wantHits: 2},
+		{name: "get gives up after max retries", method: "GET", failures: 10, maxRetries: 2, wantStatus: http.StatusServiceUnavailable, wantHits: 3},
+		{name: "get without retries", method: "GET", failures: 1, maxRetries: 0, wantStatus: http.StatusServiceUnavailable, wantHits: 1},
+		{name: "post is never retried", method: "POST", failures: 1, maxRetries: 3, wantStatus: http.StatusServiceUnavailable, wantHits: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, hits := newFlakyServer(tc.failures)
+			defer srv.Close()
+
+			c := initClient(srv.URL, "admin", MaxRetries(tc.maxRetries), BackoffMinDelay(0))
+			req, err := http.NewRequest(tc.method, srv.URL+"/api/v1/schemas", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := c.doWithRetry(req)
+			if err != nil {
+				t.Fatalf("doWithRetry returned error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if got := atomic.LoadInt32(hits); got != tc.wantHits {
+				t.Errorf("server hit %d times, want %d", got, tc.wantHits)
+			}
+		})
+	}
+}
+
+func TestDoWithRetryCancelled(t *testing.T) {
+	srv, hits := newFlakyServer(10)
+	defer srv.Close()
+
+	// a long backoff, so only the cancellation can end the wait
+	c := initClient(srv.URL, "admin", MaxRetries(3), BackoffMinDelay(30))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", srv.URL+"/api/v1/schemas", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err = c.doWithRetry(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("doWithRetry error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("doWithRetry waited %v after the context was cancelled", elapsed)
+	}
+	if got := atomic.LoadInt32(hits); got != 1 {
+		t.Errorf("server hit %d times, want 1", got)
+	}
+}
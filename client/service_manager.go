@@ -1,9 +1,12 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"strconv"
 	"strings"
 
 	"steampipe-plugin-ndo/container"
@@ -40,7 +43,7 @@ func (sm *ServiceManager) Get(dn string) (*container.Container, error) {
 		return nil, errors.New("Empty response body")
 	}
 	log.Printf("[DEBUG] Exit from GET %s", finalURL)
-	return obj, CheckForErrors(obj, "GET", sm.client.skipLoggingPayload)
+	return obj, withPath(CheckForErrors(obj, "GET", sm.client.skipLoggingPayload), finalURL)
 }
 
 func createJsonPayload(payload map[string]string) (*container.Container, error) {
@@ -67,17 +70,56 @@ func G(cont *container.Container, key string) string {
 
 // CheckForErrors parses the response and checks of there is an error attribute in the response
 func CheckForErrors(cont *container.Container, method string, skipLoggingPayload bool) error {
-	return nil
+	if cont == nil {
+		return nil
+	}
+
+	code, message, isError := parseErrorEnvelope(cont)
+	if !isError {
+		return nil
+	}
+
+	// MSO reports some failures in a 2xx body as {"code": 4xx, "message": ..},
+	// a message without an error code is informational
+	statusCode, err := strconv.Atoi(code)
+	if err != nil || statusCode < 400 {
+		if cont.Exists("message") {
+			return nil
+		}
+		statusCode = http.StatusInternalServerError
+	}
+
+	if !skipLoggingPayload {
+		log.Printf("[DEBUG] %s returned error body %s", method, cont.String())
+	}
+	return &APIError{
+		StatusCode: statusCode,
+		Code:       code,
+		Message:    message,
+	}
+}
+
+// withPath records the request path on an APIError
+func withPath(err error, path string) error {
+	if apiErr, ok := err.(*APIError); ok && apiErr.Path == "" {
+		apiErr.Path = path
+	}
+	return err
 }
 
 func (sm *ServiceManager) GetViaURL(url string) (*container.Container, error) {
-	req, err := sm.client.MakeRestRequest("GET", url, nil, true)
+	return sm.GetViaURLWithContext(context.Background(), url)
+}
+
+// GetViaURLWithContext is GetViaURL aborting the request when ctx is cancelled
+func (sm *ServiceManager) GetViaURLWithContext(ctx context.Context, url string) (*container.Container, error) {
+	req, err := sm.client.MakeRestRequestWithContext(ctx, "GET", url, nil, true)
 
 	if err != nil {
 		return nil, err
 	}
 
-	obj, _, err := sm.client.Do(req)
+	obj, _, err := sm.client.DoWithContext(ctx, req)
 	if !sm.client.skipLoggingPayload {
 		log.Printf("Getvia url %+v", obj)
 	}
@@ -88,6 +130,6 @@ func (sm *ServiceManager) GetViaURL(url string) (*container.Container, error) {
 	if obj == nil {
 		return nil, errors.New("Empty response body")
 	}
-	return obj, CheckForErrors(obj, "GET", sm.client.skipLoggingPayload)
+	return obj, withPath(CheckForErrors(obj, "GET", sm.client.skipLoggingPayload), url)
 
 }
@@ -29,6 +29,7 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"strconv"
 	"strings"
 )
 
@@ -179,6 +180,67 @@ func (g *Container) ChildrenMap() (map[string]*Container, error) {
 	return nil, ErrNotObj
 }
 
+// Bool - Return the boolean at a path, also accepting "true" and "false" strings. Returns false if
+// the path does not exist or holds another type.
+func (g *Container) Bool(hierarchy ...string) bool {
+	switch value := g.Search(hierarchy...).Data().(type) {
+	case bool:
+		return value
+	case string:
+		parsed, err := strconv.ParseBool(value)
+		return err == nil && parsed
+	}
+	return false
+}
+
+// Int - Return the integer at a path, also accepting numeric strings. Returns 0 if the path does
+// not exist or holds another type.
+func (g *Container) Int(hierarchy ...string) int64 {
+	switch value := g.Search(hierarchy...).Data().(type) {
+	case float64:
+		return int64(value)
+	case json.Number:
+		parsed, _ := value.Int64()
+		return parsed
+	case string:
+		parsed, _ := strconv.ParseInt(value, 10, 64)
+		return parsed
+	}
+	return 0
+}
+
+// Str - Return the value at a path as a plain, unquoted string. Numbers and booleans are formatted,
+// objects and arrays are returned as JSON. Returns "" if the path does not exist or is null. This
+// is not named String as that converts the whole container to JSON.
+func (g *Container) Str(hierarchy ...string) string {
+	target := g.Search(hierarchy...)
+	switch value := target.Data().(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return target.String()
+}
+
+// Strs - Return the array at a path as plain strings, formatting each element as Str does. Returns
+// nil if the path does not exist or is not an array.
+func (g *Container) Strs(hierarchy ...string) []string {
+	array, ok := g.Search(hierarchy...).Data().([]interface{})
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(array))
+	for _, obj := range array {
+		values = append(values, (&Container{obj}).Str())
+	}
+	return values
+}
+
 //--------------------------------------------------------------------------------------------------
 
 // Set - Set the value of a field at a JSON path, any parts of the path that do not exist will be
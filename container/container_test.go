@@ -0,0 +1,170 @@
+package container
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const accessorSample = `{
+	"name": "web",
+	"enabled": true,
+	"disabled": false,
+	"enabledString": "true",
+	"disabledString": "false",
+	"otherString": "yes",
+	"count": 42,
+	"negative": -7,
+	"fraction": 2.5,
+	"countString": "17",
+	"badCountString": "seventeen",
+	"empty": "",
+	"null": null,
+	"object": {"key": "value", "nested": {"id": 3}},
+	"list": ["a", 1, true, null, {"k": "v"}],
+	"emptyList": []
+}`
+
+func parseSample(t *testing.T, useNumber bool) *Container {
+	t.Helper()
+	decoder := json.NewDecoder(strings.NewReader(accessorSample))
+	if useNumber {
+		decoder.UseNumber()
+	}
+	cont, err := ParseJSONDecoder(decoder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cont
+}
+
+func TestBool(t *testing.T) {
+	cont := parseSample(t, false)
+	cases := []struct {
+		path []string
+		want bool
+	}{
+		{path: []string{"enabled"}, want: true},
+		{path: []string{"disabled"}, want: false},
+		{path: []string{"enabledString"}, want: true},
+		{path: []string{"disabledString"}, want: false},
+		{path: []string{"otherString"}, want: false},
+		{path: []string{"count"}, want: false},
+		{path: []string{"object"}, want: false},
+		{path: []string{"null"}, want: false},
+		{path: []string{"missing"}, want: false},
+		{path: []string{"object", "missing"}, want: false},
+	}
+
+	for _, tc := range cases {
+		if got := cont.Bool(tc.path...); got != tc.want {
+			t.Errorf("Bool(%v) = %v, want %v", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestInt(t *testing.T) {
+	cases := []struct {
+		path      []string
+		useNumber bool
+		want      int64
+	}{
+		{path: []string{"count"}, want: 42},
+		{path: []string{"negative"}, want: -7},
+		{path: []string{"fraction"}, want: 2},
+		{path: []string{"count"}, useNumber: true, want: 42},
+		{path: []string{"negative"}, useNumber: true, want: -7},
+		{path: []string{"fraction"}, useNumber: true, want: 0},
+		{path: []string{"countString"}, want: 17},
+		{path: []string{"badCountString"}, want: 0},
+		{path: []string{"object", "nested", "id"}, want: 3},
+		{path: []string{"enabled"}, want: 0},
+		{path: []string{"object"}, want: 0},
+		{path: []string{"null"}, want: 0},
+		{path: []string{"missing"}, want: 0},
+	}
+
+	for _, tc := range cases {
+		cont := parseSample(t, tc.useNumber)
+		if got := cont.Int(tc.path...); got != tc.want {
+			t.Errorf("Int(%v) with useNumber %v = %d, want %d", tc.path, tc.useNumber, got, tc.want)
+		}
+	}
+}
+
+func TestStr(t *testing.T) {
+	cases := []struct {
+		path      []string
+		useNumber bool
+		want      string
+	}{
+		{path: []string{"name"}, want: "web"},
+		{path: []string{"empty"}, want: ""},
+		{path: []string{"enabled"}, want: "true"},
+		{path: []string{"disabled"}, want: "false"},
+		{path: []string{"count"}, want: "42"},
+		{path: []string{"negative"}, want: "-7"},
+		{path: []string{"fraction"}, want: "2.5"},
+		{path: []string{"count"}, useNumber: true, want: "42"},
+		{path: []string{"object", "key"}, want: "value"},
+		{path: []string{"object", "nested"}, want: `{"id":3}`},
+		{path: []string{"emptyList"}, want: `[]`},
+		{path: []string{"null"}, want: ""},
+		{path: []string{"missing"}, want: ""},
+		{path: []string{"name", "missing"}, want: ""},
+	}
+
+	for _, tc := range cases {
+		cont := parseSample(t, tc.useNumber)
+		if got := cont.Str(tc.path...); got != tc.want {
+			t.Errorf("Str(%v) with useNumber %v = %q, want %q", tc.path, tc.useNumber, got, tc.want)
+		}
+	}
+
+	// large ids must not be formatted in exponent notation
+	cont, err := ParseJSON([]byte(`{"id": 1700000000123}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cont.Str("id"); got != "1700000000123" {
+		t.Errorf("Str(id) = %q, want %q", got, "1700000000123")
+	}
+}
+
+func TestStrs(t *testing.T) {
+	cont := parseSample(t, false)
+	cases := []struct {
+		path []string
+		want []string
+	}{
+		{path: []string{"list"}, want: []string{"a", "1", "true", "", `{"k":"v"}`}},
+		{path: []string{"emptyList"}, want: []string{}},
+		{path: []string{"name"}, want: nil},
+		{path: []string{"object"}, want: nil},
+		{path: []string{"null"}, want: nil},
+		{path: []string{"missing"}, want: nil},
+	}
+
+	for _, tc := range cases {
+		if got := cont.Strs(tc.path...); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Strs(%v) = %#v, want %#v", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestAccessorsOnNilContainer(t *testing.T) {
+	var cont *Container
+	if got := cont.Str("name"); got != "" {
+		t.Errorf("Str on nil container = %q, want empty", got)
+	}
+	if got := cont.Bool("enabled"); got {
+		t.Errorf("Bool on nil container = %v, want false", got)
+	}
+	if got := cont.Int("count"); got != 0 {
+		t.Errorf("Int on nil container = %d, want 0", got)
+	}
+	if got := cont.Strs("list"); got != nil {
+		t.Errorf("Strs on nil container = %#v, want nil", got)
+	}
+}
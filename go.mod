@@ -2,7 +2,10 @@ module steampipe-plugin-ndo
 
 go 1.17
 
-require github.com/turbot/steampipe-plugin-sdk v1.8.3
+require (
+	github.com/turbot/steampipe-plugin-sdk v1.8.3
+	golang.org/x/sync v0.0.0-20220907140024-f12130a52804
+)
 
 require (
 	github.com/acarl005/stripansi v0.0.0-20180116102854-5a71ef0e047d // indirect
@@ -12,6 +12,10 @@ type NDOConfig struct {
 	Password           *string `cty:"password"`
 	LoginDomain        *string `cty:"login_domain"`
 	Platform           *string `cty:"platform"`
+	MaxRetries         *int    `cty:"max_retries"`
+	BackoffMinDelay    *int    `cty:"backoff_min_delay"`
+	BackoffMaxDelay    *int    `cty:"backoff_max_delay"`
+	MaxConcurrency     *int    `cty:"max_concurrency"`
 }
 
 var ConfigSchema = map[string]*schema.Attribute{
@@ -33,6 +37,18 @@ var ConfigSchema = map[string]*schema.Attribute{
 	"platform": {
 		Type: schema.TypeString,
 	},
+	"max_retries": {
+		Type: schema.TypeInt,
+	},
+	"backoff_min_delay": {
+		Type: schema.TypeInt,
+	},
+	"backoff_max_delay": {
+		Type: schema.TypeInt,
+	},
+	"max_concurrency": {
+		Type: schema.TypeInt,
+	},
 }
 
 func ConfigInstance() interface{} {
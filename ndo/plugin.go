@@ -23,14 +23,38 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			Schema:      ConfigSchema,
 		},
 		DefaultTransform: transform.FromGo().NullIfZero(),
+		DefaultGetConfig: &plugin.GetConfig{
+			ShouldIgnoreError: isNotFoundError,
+		},
 		TableMap: map[string]*plugin.Table{
-			"ndo_epg_static_port":         tableNDOEpgStaticPort(),
-			"ndo_schema":                  tableNDOSchema(),
-			"ndo_schema_template":         tableNDOSchemaTemplate(),
-			"ndo_schema_template_anp":     tableNDOSchemaTemplateAnp(),
-			"ndo_schema_template_vrf":     tableNDOSchemaTemplateVrf(),
-			"ndo_schema_template_bd":      tableNDOSchemaTemplateBd(),
-			"ndo_schema_template_anp_epg": tableNDOSchemaTemplateAnpEpg(),
+			"ndo_epg_static_port":                       tableNDOEpgStaticPort(),
+			"ndo_schema":                                tableNDOSchema(),
+			"ndo_schema_site_anp_epg_static_leaf":       tableNDOSchemaSiteAnpEpgStaticLeaf(),
+			"ndo_schema_site_anp_epg_selector":          tableNDOSchemaSiteAnpEpgSelector(),
+			"ndo_schema_site_anp_epg_subnet":            tableNDOSchemaSiteAnpEpgSubnet(),
+			"ndo_schema_site_anp_epg_domain":            tableNDOSchemaSiteAnpEpgDomain(),
+			"ndo_schema_site_bd":                        tableNDOSchemaSiteBd(),
+			"ndo_schema_site_bd_subnet":                 tableNDOSchemaSiteBdSubnet(),
+			"ndo_schema_site_bd_l3out":                  tableNDOSchemaSiteBdL3out(),
+			"ndo_schema_template":                       tableNDOSchemaTemplate(),
+			"ndo_schema_template_anp":                   tableNDOSchemaTemplateAnp(),
+			"ndo_schema_template_vrf":                   tableNDOSchemaTemplateVrf(),
+			"ndo_schema_template_vrf_contract":          tableNDOSchemaTemplateVrfContract(),
+			"ndo_schema_template_bd":                    tableNDOSchemaTemplateBd(),
+			"ndo_schema_template_bd_dhcp_label":         tableNDOSchemaTemplateBdDhcpLabel(),
+			"ndo_schema_template_bd_subnet":             tableNDOSchemaTemplateBdSubnet(),
+			"ndo_schema_template_anp_epg":               tableNDOSchemaTemplateAnpEpg(),
+			"ndo_schema_template_anp_epg_contract":      tableNDOSchemaTemplateAnpEpgContract(),
+			"ndo_schema_template_anp_epg_selector":      tableNDOSchemaTemplateAnpEpgSelector(),
+			"ndo_schema_template_anp_epg_useg_attr":     tableNDOSchemaTemplateAnpEpgUsegAttr(),
+			"ndo_schema_template_anp_epg_subnet":        tableNDOSchemaTemplateAnpEpgSubnet(),
+			"ndo_schema_template_external_epg":          tableNDOSchemaTemplateExternalEpg(),
+			"ndo_schema_template_external_epg_subnet":   tableNDOSchemaTemplateExternalEpgSubnet(),
+			"ndo_schema_template_external_epg_contract": tableNDOSchemaTemplateExternalEpgContract(),
+			"ndo_schema_template_l3out":                 tableNDOSchemaTemplateL3out(),
+			"ndo_schema_template_contract":              tableNDOSchemaTemplateContract(),
+			"ndo_schema_template_filter":                tableNDOSchemaTemplateFilter(),
+			"ndo_schema_template_filter_entry":          tableNDOSchemaTemplateFilterEntry(),
 		},
 	}
 	return p
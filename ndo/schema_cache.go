@@ -0,0 +1,78 @@
+package ndo
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"steampipe-plugin-ndo/client"
+	"steampipe-plugin-ndo/container"
+
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"golang.org/x/sync/singleflight"
+)
+
+// How long the identity list and schema documents are shared between tables.
+// Long enough to cover a multi-table query, short enough that edits in NDO
+// show up on the next query.
+const schemaCacheTTL = 2 * time.Minute
+
+// tables scanned concurrently wait for the fetch already in flight for a cache
+// key instead of issuing the same request again
+var schemaFetchGroup singleflight.Group
+
+func getSchemaIdentityList(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client) (*container.Container, error) {
+	log.Printf("[DEBUG] Calling API: list-identity")
+	return getCachedSchemaURL(ctx, d, ndoclient, "/api/v1/schemas/list-identity")
+}
+
+func getSchemaDetails(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client, schemaId string) (*container.Container, error) {
+	return getCachedSchemaURL(ctx, d, ndoclient, "/api/v1/schemas/"+schemaId)
+}
+
+// getCachedSchemaURL returns the document at dnUrl from the connection cache,
+// fetching and caching it on a miss
+func getCachedSchemaURL(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client, dnUrl string) (*container.Container, error) {
+	cacheKey := "ndo-" + d.Connection.Name + dnUrl
+
+	for {
+		if cached, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+			log.Printf("[TRACE] Cache hit: %v", dnUrl)
+			return cached.(*container.Container), nil
+		}
+
+		fetch := schemaFetchGroup.DoChan(cacheKey, func() (interface{}, error) {
+			if cached, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+				return cached, nil
+			}
+			obj, err := ndoclient.ServiceManager.GetViaURLWithContext(ctx, dnUrl)
+			if err != nil {
+				return nil, err
+			}
+			d.ConnectionManager.Cache.SetWithTTL(cacheKey, obj, schemaCacheTTL)
+			return obj, nil
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case result := <-fetch:
+			// the fetch runs with the context of the query that started it, so
+			// fetch again if only that query was cancelled
+			if result.Err != nil && ctx.Err() == nil && isContextError(result.Err) {
+				log.Printf("[DEBUG] Shared fetch of %v was cancelled, fetching again", dnUrl)
+				continue
+			}
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			return result.Val.(*container.Container), nil
+		}
+	}
+}
+
+// isContextError reports whether err comes from a cancelled or expired context
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
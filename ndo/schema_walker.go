@@ -0,0 +1,510 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"steampipe-plugin-ndo/client"
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+const defaultMaxConcurrency = 10
+
+type schemaResult struct {
+	schemaId string
+	schema   *container.Container
+	err      error
+}
+
+// forEachSchema fetches the document of every schema in the identity list, up to
+// max_concurrency at a time, and calls fn with each one as it arrives. fn is only
+// ever called from the calling goroutine. The walk stops early once the query
+// needs no more rows. A schema_id qual skips the identity list and fetches only
+// that schema.
+func forEachSchema(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client, fn func(schemaId string, schemaDetails *container.Container) error) error {
+	schemaIds, err := listSchemaIds(ctx, d, ndoclient)
+	if err != nil {
+		return err
+	}
+
+	walkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ids := make(chan string)
+	results := make(chan schemaResult)
+
+	go func() {
+		defer close(ids)
+		for _, schemaId := range schemaIds {
+			select {
+			case ids <- schemaId:
+			case <-walkCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < getMaxConcurrency(d); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for schemaId := range ids {
+				schemaDetails, err := getSchemaDetails(walkCtx, d, ndoclient, schemaId)
+				select {
+				case results <- schemaResult{schemaId: schemaId, schema: schemaDetails, err: err}:
+				case <-walkCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		if result.err != nil {
+			// the schema may have been deleted since the identity list was read
+			if client.IsNotFound(result.err) {
+				log.Printf("[DEBUG] Schema not found, skipping: %v", result.schemaId)
+				continue
+			}
+			return fmt.Errorf("Error getting Schema %v: %v", result.schemaId, result.err)
+		}
+
+		err := fn(result.schemaId, result.schema)
+		if err != nil {
+			return err
+		}
+
+		// stop once the query's limit is satisfied or it was cancelled
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// listSchemaIds returns the id in the schema_id qual, or every schema id in the identity list
+func listSchemaIds(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client) ([]string, error) {
+	if schemaId := qualString(d, "schema_id"); schemaId != "" {
+		return []string{schemaId}, nil
+	}
+
+	dnUrl := "/api/v1/schemas/list-identity"
+	identityList, err := getSchemaIdentityList(ctx, d, ndoclient)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Identity List: %v\nURL: %v", err, dnUrl)
+	}
+
+	log.Printf("[TRACE] Indentity List: %v", identityList)
+	schemaobjlist, err := identityList.S("schemas").Children()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Schema List: %v", err)
+	}
+
+	schemaIds := make([]string, 0, len(schemaobjlist))
+	for _, curschema := range schemaobjlist {
+		schemaIds = append(schemaIds, curschema.Str("id"))
+	}
+	return schemaIds, nil
+}
+
+func getMaxConcurrency(d *plugin.QueryData) int {
+	ndoConfig := GetConfig(d.Connection)
+	if ndoConfig.MaxConcurrency != nil && *ndoConfig.MaxConcurrency > 0 {
+		return *ndoConfig.MaxConcurrency
+	}
+	return defaultMaxConcurrency
+}
+
+// forEachTemplate calls fn with every template of every schema, skipping those
+// that do not match the template_name qual
+func forEachTemplate(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client, fn func(schemaId string, curtemp *container.Container) error) error {
+	return forEachSchema(ctx, d, ndoclient, func(schemaId string, schemaDetails *container.Container) error {
+		templatelist, err := schemaDetails.S("templates").Children()
+		if err != nil {
+			return fmt.Errorf("Error getting template list: %v", err)
+		}
+
+		for _, curtemp := range templatelist {
+			if !matchesQual(d, "template_name", curtemp.Str("name")) {
+				continue
+			}
+
+			err := fn(schemaId, curtemp)
+			if err != nil {
+				return err
+			}
+
+			// stop once the query's limit is satisfied or it was cancelled
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		return nil
+	})
+}
+
+// forEachTemplateBd calls fn with every BD of every template, skipping templates
+// that do not match the template_name qual and BDs that do not match the qual on
+// bdNameColumn. The walk stops early once the query needs no more rows.
+func forEachTemplateBd(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client, bdNameColumn string, fn func(schemaId string, curtemp *container.Container, curbd *container.Container) error) error {
+	return forEachTemplate(ctx, d, ndoclient, func(schemaId string, curtemp *container.Container) error {
+		bdobjlist, err := curtemp.S("bds").Children()
+		if err != nil {
+			return fmt.Errorf("Error getting Bd list: %v", err)
+		}
+
+		for _, curbd := range bdobjlist {
+			if !matchesQual(d, bdNameColumn, curbd.Str("name")) {
+				continue
+			}
+
+			err := fn(schemaId, curtemp, curbd)
+			if err != nil {
+				return err
+			}
+
+			// stop once the query's limit is satisfied or it was cancelled
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		return nil
+	})
+}
+
+// forEachTemplateEpg calls fn with every EPG of every ANP of every template,
+// skipping those that do not match the template_name and anp_name quals or the
+// qual on epgNameColumn. The walk stops early once the query needs no more rows.
+func forEachTemplateEpg(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client, epgNameColumn string, fn func(schemaId string, curtemp *container.Container, curanp *container.Container, curepg *container.Container) error) error {
+	return forEachTemplate(ctx, d, ndoclient, func(schemaId string, curtemp *container.Container) error {
+		anpobjlist, err := curtemp.S("anps").Children()
+		if err != nil {
+			return fmt.Errorf("Error getting anp list: %v", err)
+		}
+
+		for _, curanp := range anpobjlist {
+			if !matchesQual(d, "anp_name", curanp.Str("name")) {
+				continue
+			}
+
+			epgobjlist, err := curanp.S("epgs").Children()
+			if err != nil {
+				return fmt.Errorf("Error getting epg list: %v", err)
+			}
+
+			for _, curepg := range epgobjlist {
+				if !matchesQual(d, epgNameColumn, curepg.Str("name")) {
+					continue
+				}
+
+				err := fn(schemaId, curtemp, curanp, curepg)
+				if err != nil {
+					return err
+				}
+
+				// stop once the query's limit is satisfied or it was cancelled
+				if d.QueryStatus.RowsRemaining(ctx) == 0 {
+					return nil
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// forEachTemplateFilter calls fn with every filter of every template, skipping
+// those that do not match the template_name qual or the qual on filterNameColumn
+func forEachTemplateFilter(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client, filterNameColumn string, fn func(schemaId string, curtemp *container.Container, curfilter *container.Container) error) error {
+	return forEachTemplate(ctx, d, ndoclient, func(schemaId string, curtemp *container.Container) error {
+		filterlist, err := curtemp.S("filters").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, curfilter := range filterlist {
+			if !matchesQual(d, filterNameColumn, curfilter.Str("name")) {
+				continue
+			}
+
+			err := fn(schemaId, curtemp, curfilter)
+			if err != nil {
+				return err
+			}
+
+			// stop once the query's limit is satisfied or it was cancelled
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		return nil
+	})
+}
+
+// forEachTemplateExternalEpg calls fn with every external EPG of every template,
+// skipping those that do not match the template_name qual or the qual on
+// externalEpgNameColumn
+func forEachTemplateExternalEpg(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client, externalEpgNameColumn string, fn func(schemaId string, curtemp *container.Container, curextepg *container.Container) error) error {
+	return forEachTemplate(ctx, d, ndoclient, func(schemaId string, curtemp *container.Container) error {
+		extepglist, err := curtemp.S("externalEpgs").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, curextepg := range extepglist {
+			if !matchesQual(d, externalEpgNameColumn, curextepg.Str("name")) {
+				continue
+			}
+
+			err := fn(schemaId, curtemp, curextepg)
+			if err != nil {
+				return err
+			}
+
+			// stop once the query's limit is satisfied or it was cancelled
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		return nil
+	})
+}
+
+// forEachSite calls fn with every site of every schema, skipping those that do
+// not match the site_id and template_name quals
+func forEachSite(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client, fn func(schemaId string, cursite *container.Container) error) error {
+	return forEachSchema(ctx, d, ndoclient, func(schemaId string, schemaDetails *container.Container) error {
+		sitelist, err := schemaDetails.S("sites").Children()
+		if err != nil {
+			return fmt.Errorf("Error getting site list: %v", err)
+		}
+
+		for _, cursite := range sitelist {
+			if !matchesQual(d, "site_id", cursite.Str("siteId")) || !matchesQual(d, "template_name", cursite.Str("templateName")) {
+				continue
+			}
+
+			err := fn(schemaId, cursite)
+			if err != nil {
+				return err
+			}
+
+			// stop once the query's limit is satisfied or it was cancelled
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		return nil
+	})
+}
+
+// forEachSiteBd calls fn with the site-local part of every BD of every site,
+// skipping BDs that do not match the qual on bdNameColumn. Site BDs carry no
+// name of their own, fn gets the parsed bdRef to name them.
+func forEachSiteBd(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client, bdNameColumn string, fn func(schemaId string, cursite *container.Container, bdRef *ref.ObjectRef, curbd *container.Container) error) error {
+	return forEachSite(ctx, d, ndoclient, func(schemaId string, cursite *container.Container) error {
+		bdobjlist, err := cursite.S("bds").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, curbd := range bdobjlist {
+			bdRef, err := ref.ParseKind(curbd.Str("bdRef"), "bds")
+			if err != nil {
+				log.Printf("[WARN] Skipping site BD in schema %s: %v", schemaId, err)
+				continue
+			}
+			if !matchesQual(d, bdNameColumn, bdRef.Name()) {
+				continue
+			}
+
+			err = fn(schemaId, cursite, bdRef, curbd)
+			if err != nil {
+				return err
+			}
+
+			// stop once the query's limit is satisfied or it was cancelled
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		return nil
+	})
+}
+
+// forEachSiteEpg calls fn with the site-local part of every EPG of every site,
+// skipping EPGs that do not match the anp_name and epg_name quals. Site EPGs
+// carry no names of their own, fn gets the parsed epgRef to name them.
+func forEachSiteEpg(ctx context.Context, d *plugin.QueryData, ndoclient *client.Client, fn func(schemaId string, cursite *container.Container, epgRef *ref.ObjectRef, curepg *container.Container) error) error {
+	return forEachSite(ctx, d, ndoclient, func(schemaId string, cursite *container.Container) error {
+		anpobjlist, err := cursite.S("anps").Children()
+		if err != nil {
+			return fmt.Errorf("Error getting anp list: %v", err)
+		}
+
+		for _, curanp := range anpobjlist {
+			epgobjlist, err := curanp.S("epgs").Children()
+			if err != nil {
+				return fmt.Errorf("Error getting epg list: %v", err)
+			}
+
+			for _, curepg := range epgobjlist {
+				epgRef, err := ref.ParseKind(curepg.Str("epgRef"), "epgs")
+				if err != nil {
+					log.Printf("[WARN] Skipping site EPG in schema %s: %v", schemaId, err)
+					continue
+				}
+				if !matchesQual(d, "anp_name", epgRef.NameOf("anps")) || !matchesQual(d, "epg_name", epgRef.Name()) {
+					continue
+				}
+
+				err = fn(schemaId, cursite, epgRef, curepg)
+				if err != nil {
+					return err
+				}
+
+				// stop once the query's limit is satisfied or it was cancelled
+				if d.QueryStatus.RowsRemaining(ctx) == 0 {
+					return nil
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// findSiteEpg returns the site, the parsed epgRef and the site-local part of the
+// EPG named epgName under anpName in the site of the schema document, or nils
+func findSiteEpg(schemaDetails *container.Container, siteId string, templateName string, anpName string, epgName string) (*container.Container, *ref.ObjectRef, *container.Container) {
+	sitelist, err := schemaDetails.S("sites").Children()
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	for _, cursite := range sitelist {
+		if cursite.Str("siteId") != siteId || cursite.Str("templateName") != templateName {
+			continue
+		}
+
+		anpobjlist, err := cursite.S("anps").Children()
+		if err != nil {
+			return nil, nil, nil
+		}
+
+		for _, curanp := range anpobjlist {
+			epgobjlist, err := curanp.S("epgs").Children()
+			if err != nil {
+				continue
+			}
+
+			for _, curepg := range epgobjlist {
+				epgRef, err := ref.ParseKind(curepg.Str("epgRef"), "epgs")
+				if err == nil && epgRef.NameOf("anps") == anpName && epgRef.Name() == epgName {
+					return cursite, epgRef, curepg
+				}
+			}
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// findSiteBd returns the site, the parsed bdRef and the site-local part of the BD
+// named bdName in the site of the schema document, or nils
+func findSiteBd(schemaDetails *container.Container, siteId string, templateName string, bdName string) (*container.Container, *ref.ObjectRef, *container.Container) {
+	sitelist, err := schemaDetails.S("sites").Children()
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	for _, cursite := range sitelist {
+		if cursite.Str("siteId") != siteId || cursite.Str("templateName") != templateName {
+			continue
+		}
+
+		bdobjlist, err := cursite.S("bds").Children()
+		if err != nil {
+			return nil, nil, nil
+		}
+
+		for _, curbd := range bdobjlist {
+			bdRef, err := ref.ParseKind(curbd.Str("bdRef"), "bds")
+			if err == nil && bdRef.Name() == bdName {
+				return cursite, bdRef, curbd
+			}
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// siteBdL3out is one L3Out a site BD is advertised out. Only l3OutRefs entries
+// say which schema and template define the L3Out.
+type siteBdL3out struct {
+	name         string
+	schemaId     string
+	templateName string
+}
+
+// siteBdL3outs returns the L3Outs of a site BD. Older NDO versions list their names
+// in l3Outs, newer ones reference them in l3OutRefs, either as a reference string or
+// as an object with the schema, template and L3Out name. An L3Out found in both
+// lists is returned once.
+func siteBdL3outs(curbd *container.Container) []siteBdL3out {
+	l3outs := []siteBdL3out{}
+	seen := map[string]bool{}
+
+	if reflist, err := curbd.S("l3OutRefs").Children(); err == nil {
+		for _, curref := range reflist {
+			l3out := siteBdL3out{}
+			if refStr, ok := curref.Data().(string); ok {
+				l3outRef, err := ref.ParseKind(refStr, "l3outs")
+				if err != nil {
+					log.Printf("[WARN] Site BD %s: %v", curbd.Str("bdRef"), err)
+					continue
+				}
+				l3out.name = l3outRef.Name()
+				l3out.schemaId = l3outRef.SchemaId
+				l3out.templateName = l3outRef.TemplateName
+			} else {
+				l3out.name = curref.Str("l3outName")
+				l3out.schemaId = curref.Str("schemaId")
+				l3out.templateName = curref.Str("templateName")
+			}
+			if l3out.name == "" || seen[l3out.name] {
+				continue
+			}
+			seen[l3out.name] = true
+			l3outs = append(l3outs, l3out)
+		}
+	}
+
+	for _, l3outName := range curbd.Strs("l3Outs") {
+		if l3outName == "" || seen[l3outName] {
+			continue
+		}
+		seen[l3outName] = true
+		l3outs = append(l3outs, siteBdL3out{name: l3outName})
+	}
+
+	return l3outs
+}
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"log"
 
-	"steampipe-plugin-ndo/client"
-
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
@@ -30,6 +28,14 @@ func tableNDOSchema() *plugin.Table {
 					Name:    "id",
 					Require: plugin.Optional,
 				},
+				{
+					Name:    "name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
 			},
 		},
 		Columns: []*plugin.Column{
@@ -55,6 +61,13 @@ func tableNDOSchema() *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("TenantId"),
 			},
+			{
+				Name:        "raw",
+				Description: "The full schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getSchemaRaw,
+				Transform:   transform.FromValue(),
+			},
 		},
 	}
 }
@@ -67,9 +80,8 @@ func listSchema(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, fmt.Errorf("Error connecting to ND: %v", err)
 	}
 
-	log.Printf("[DEBUG] Calling API: list-identity")
 	dnUrl := "/api/v1/schemas/list-identity"
-	identityList, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
+	identityList, err := getSchemaIdentityList(ctx, d, ndoclient)
 
 	if err != nil {
 		return nil, fmt.Errorf("Error getting Identity List: %v\nURL: %v", err, dnUrl)
@@ -82,6 +94,10 @@ func listSchema(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 	}
 
 	for _, curschema := range identityListChildren {
+		if !matchesQual(d, "id", curschema.Str("id")) || !matchesQual(d, "name", curschema.Str("displayName")) {
+			continue
+		}
+
 		log.Printf("[TRACE] Processing Schema: %v", curschema)
 		templateList, err := curschema.S("templates").Children()
 		if err != nil {
@@ -89,14 +105,18 @@ func listSchema(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		}
 
 		for _, curtemplate := range templateList {
+			if !matchesQual(d, "template_name", curtemplate.Str("name")) {
+				continue
+			}
+
 			log.Printf("[TRACE] Processing Template: %v", curtemplate)
 			log.Printf("[TRACE] inside schema: %v", curschema)
 
 			schemaObj := Schema{}
-			schemaObj.Id = client.StripQuotes(curschema.S("id").String())
-			schemaObj.Name = client.StripQuotes(curschema.S("displayName").String())
-			schemaObj.TemplateName = client.StripQuotes(curtemplate.S("name").String())
-			schemaObj.TenantId = client.StripQuotes(curtemplate.S("tenantId").String())
+			schemaObj.Id = curschema.Str("id")
+			schemaObj.Name = curschema.Str("displayName")
+			schemaObj.TemplateName = curtemplate.Str("name")
+			schemaObj.TenantId = curtemplate.Str("tenantId")
 
 			log.Printf("[TRACE] Built Schema Object: %v", schemaObj)
 
@@ -106,3 +126,20 @@ func listSchema(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 
 	return nil, nil
 }
+
+//// HYDRATE FUNCTIONS
+func getSchemaRaw(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	schemaObj := h.Item.(Schema)
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, schemaObj.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return schemaDetails.Data(), nil
+}
@@ -0,0 +1,298 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaSiteAnpEpgDomain struct {
+	Id                     string
+	SchemaId               string
+	SiteId                 string
+	TemplateName           string
+	AnpName                string
+	EpgName                string
+	DomainType             string
+	DomainName             string
+	DomainDn               string
+	DeployImmediacy        string
+	ResolutionImmediacy    string
+	VlanEncapMode          string
+	AllowMicroSegmentation bool
+	MicroSegVlanType       string
+	MicroSegVlan           int64
+	PortEncapVlanType      string
+	PortEncapVlan          int64
+	EnhancedLagPolicyName  string
+	EnhancedLagPolicyDn    string
+	SwitchingMode          string
+	SwitchType             string
+	Raw                    interface{}
+}
+
+func tableNDOSchemaSiteAnpEpgDomain() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_site_anp_epg_domain",
+		Description: "NDO Schema-Site-ANP-EPG Domain Associations",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaSiteAnpEpgDomain,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "site_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "anp_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "epg_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "domain_type",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "domain_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaSiteAnpEpgDomain,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID under which the domain is associated.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "site_id",
+				Description: "(Required) SiteID under which the domain is associated.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SiteId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template name under which the domain is associated.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "anp_name",
+				Description: "(Required) ANP name under which the domain is associated.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "epg_name",
+				Description: "(Required) EPG name under which the domain is associated.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "domain_type",
+				Description: "(Required) The type of the domain. Allowed values are vmmDomain, l3ExtDomain, l2ExtDomain, physicalDomain and fibreChannelDomain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "domain_name",
+				Description: "(Required) The name of the domain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "domain_dn",
+				Description: "(Required) The DN of the domain, e.g. uni/vmmp-VMware/dom-vds1 or uni/phys-phys1.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "deploy_immediacy",
+				Description: "(Optional) The deployment immediacy of the domain. Allowed values are immediate and lazy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resolution_immediacy",
+				Description: "(Optional) The resolution immediacy of the domain. Allowed values are immediate, lazy and pre-provision.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vlan_encap_mode",
+				Description: "(Optional) The VLAN encapsulation mode of a VMM domain. Allowed values are static and dynamic.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "allow_micro_segmentation",
+				Description: "(Optional) Whether micro-segmentation is allowed on a VMM domain. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("AllowMicroSegmentation"),
+			},
+			{
+				Name:        "micro_seg_vlan_type",
+				Description: "(Optional) The type of the micro-segmentation VLAN, e.g. vlan.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "micro_seg_vlan",
+				Description: "(Optional) The micro-segmentation VLAN id of a VMM domain.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "port_encap_vlan_type",
+				Description: "(Optional) The type of the port encap VLAN, e.g. vlan.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "port_encap_vlan",
+				Description: "(Optional) The port encap VLAN id of a VMM domain.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "enhanced_lag_policy_name",
+				Description: "(Optional) The name of the enhanced LAG policy of a VMM domain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "enhanced_lag_policy_dn",
+				Description: "(Optional) The DN of the enhanced LAG policy of a VMM domain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "switching_mode",
+				Description: "(Optional) The switching mode of a VMM domain, e.g. native or AVE.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "switch_type",
+				Description: "(Optional) The switch type of a VMM domain, e.g. default.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "raw",
+				Description: "The full domain association object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaSiteAnpEpgDomain(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachSiteEpg(ctx, d, ndoclient, func(schemaId string, cursite *container.Container, epgRef *ref.ObjectRef, curepg *container.Container) error {
+		domainlist, err := curepg.S("domainAssociations").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, curdomain := range domainlist {
+			domainobj := buildSchemaSiteAnpEpgDomain(schemaId, cursite, epgRef, curdomain)
+			if !matchesQual(d, "domain_type", domainobj.DomainType) || !matchesQual(d, "domain_name", domainobj.DomainName) {
+				continue
+			}
+
+			log.Printf("[TRACE] Record object: %v ", domainobj)
+			d.StreamListItem(ctx, domainobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaSiteAnpEpgDomain(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "site", "template", "anp", "epg", "domain")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	cursite, epgRef, curepg := findSiteEpg(schemaDetails, idParts[1], idParts[2], idParts[3], idParts[4])
+	if curepg == nil {
+		return nil, nil
+	}
+
+	curdomain := findByName(curepg.S("domainAssociations"), "dn", idParts[5])
+	if curdomain == nil {
+		return nil, nil
+	}
+
+	return buildSchemaSiteAnpEpgDomain(idParts[0], cursite, epgRef, curdomain), nil
+}
+
+// domainName returns the name of the domain from its DN, the last RN without
+// its prefix, e.g. vds1 for uni/vmmp-VMware/dom-vds1
+func domainName(dn string) string {
+	rn := dn[strings.LastIndex(dn, "/")+1:]
+	if i := strings.Index(rn, "-"); i >= 0 {
+		return rn[i+1:]
+	}
+	return rn
+}
+
+func buildSchemaSiteAnpEpgDomain(schemaId string, cursite *container.Container, epgRef *ref.ObjectRef, curdomain *container.Container) *SchemaSiteAnpEpgDomain {
+	domainobj := &SchemaSiteAnpEpgDomain{}
+	domainobj.SchemaId = schemaId
+	domainobj.SiteId = cursite.Str("siteId")
+	domainobj.TemplateName = cursite.Str("templateName")
+	domainobj.AnpName = epgRef.NameOf("anps")
+	domainobj.EpgName = epgRef.Name()
+	domainobj.DomainType = curdomain.Str("domainType")
+	domainobj.DomainDn = curdomain.Str("dn")
+	domainobj.DomainName = domainName(domainobj.DomainDn)
+	domainobj.DeployImmediacy = curdomain.Str("deployImmediacy")
+	domainobj.ResolutionImmediacy = curdomain.Str("resolutionImmediacy")
+	domainobj.VlanEncapMode = curdomain.Str("vlanEncapMode")
+	domainobj.AllowMicroSegmentation = curdomain.Bool("allowMicroSegmentation")
+	domainobj.MicroSegVlanType = curdomain.Str("microSegVlan", "vlanType")
+	domainobj.MicroSegVlan = curdomain.Int("microSegVlan", "vlan")
+	domainobj.PortEncapVlanType = curdomain.Str("portEncapVlan", "vlanType")
+	domainobj.PortEncapVlan = curdomain.Int("portEncapVlan", "vlan")
+	domainobj.EnhancedLagPolicyName = curdomain.Str("epgLagPol", "enhancedLagPol", "name")
+	domainobj.EnhancedLagPolicyDn = curdomain.Str("epgLagPol", "enhancedLagPol", "dn")
+	domainobj.SwitchingMode = curdomain.Str("switchingMode")
+	domainobj.SwitchType = curdomain.Str("switchType")
+	domainobj.Raw = curdomain.Data()
+	domainobj.Id = domainobj.SchemaId + "/site/" + domainobj.SiteId + "/template/" + domainobj.TemplateName + "/anp/" + domainobj.AnpName + "/epg/" + domainobj.EpgName + "/domain/" + domainobj.DomainDn
+	return domainobj
+}
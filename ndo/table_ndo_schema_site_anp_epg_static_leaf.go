@@ -0,0 +1,202 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaSiteAnpEpgStaticLeaf struct {
+	Id           string
+	SchemaId     string
+	SiteId       string
+	TemplateName string
+	AnpName      string
+	EpgName      string
+	Pod          string
+	Leaf         string
+	PathDn       string
+	Vlan         int64
+	Raw          interface{}
+}
+
+func tableNDOSchemaSiteAnpEpgStaticLeaf() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_site_anp_epg_static_leaf",
+		Description: "NDO Schema-Site-ANP-EPG Static Leafs",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaSiteAnpEpgStaticLeaf,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "site_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "anp_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "epg_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaSiteAnpEpgStaticLeaf,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID under which the Static Leaf is deployed.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "site_id",
+				Description: "(Required) SiteID under which the Static Leaf is deployed.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SiteId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template name under which the Static Leaf is deployed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "anp_name",
+				Description: "(Required) ANP name under which the Static Leaf is deployed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "epg_name",
+				Description: "(Required) EPG name under which the Static Leaf is deployed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "pod",
+				Description: "(Required) The pod of the Static Leaf, e.g. pod-1.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "leaf",
+				Description: "(Required) The node id of the Static Leaf, e.g. 101.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "path_dn",
+				Description: "(Required) The full ACI node DN of the Static Leaf, e.g. topology/pod-1/node-101.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vlan",
+				Description: "(Required) The port encap VLAN id of the Static Leaf.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "raw",
+				Description: "The full static leaf object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaSiteAnpEpgStaticLeaf(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachSiteEpg(ctx, d, ndoclient, func(schemaId string, cursite *container.Container, epgRef *ref.ObjectRef, curepg *container.Container) error {
+		staticleaflist, err := curepg.S("staticLeafs").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, curleaf := range staticleaflist {
+			leafobj := buildSchemaSiteAnpEpgStaticLeaf(schemaId, cursite, epgRef, curleaf)
+			log.Printf("[TRACE] Record object: %v ", leafobj)
+			d.StreamListItem(ctx, leafobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaSiteAnpEpgStaticLeaf(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "site", "template", "anp", "epg", "staticLeaf")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	cursite, epgRef, curepg := findSiteEpg(schemaDetails, idParts[1], idParts[2], idParts[3], idParts[4])
+	if curepg == nil {
+		return nil, nil
+	}
+
+	curleaf := findByName(curepg.S("staticLeafs"), "path", idParts[5])
+	if curleaf == nil {
+		return nil, nil
+	}
+
+	return buildSchemaSiteAnpEpgStaticLeaf(idParts[0], cursite, epgRef, curleaf), nil
+}
+
+func buildSchemaSiteAnpEpgStaticLeaf(schemaId string, cursite *container.Container, epgRef *ref.ObjectRef, curleaf *container.Container) *SchemaSiteAnpEpgStaticLeaf {
+	leafobj := &SchemaSiteAnpEpgStaticLeaf{}
+	leafobj.SchemaId = schemaId
+	leafobj.SiteId = cursite.Str("siteId")
+	leafobj.TemplateName = cursite.Str("templateName")
+	leafobj.AnpName = epgRef.NameOf("anps")
+	leafobj.EpgName = epgRef.Name()
+	leafobj.PathDn = curleaf.Str("path")
+	node, err := ref.ParseNode(leafobj.PathDn)
+	if err != nil {
+		log.Printf("[WARN] Static leaf of EPG %s: %v", leafobj.EpgName, err)
+	} else {
+		leafobj.Pod = node.Pod
+		leafobj.Leaf = node.Leaf
+	}
+	leafobj.Vlan = curleaf.Int("portEncapVlan")
+	leafobj.Raw = curleaf.Data()
+	leafobj.Id = leafobj.SchemaId + "/site/" + leafobj.SiteId + "/template/" + leafobj.TemplateName + "/anp/" + leafobj.AnpName + "/epg/" + leafobj.EpgName + "/staticLeaf/" + leafobj.PathDn
+	return leafobj
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 
-	"steampipe-plugin-ndo/client"
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
 
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
@@ -23,12 +23,16 @@ type SchemaSiteAnpEpgStaticPort struct {
 	PathType            string
 	Pod                 string
 	Leaf                string
+	LeafA               string
+	LeafB               string
+	FexId               string
 	Path                string
+	PathDn              string
 	Mode                string
 	DeploymentImmediacy string
-	Vlan                string
-	MicroSegVlan        string
-	Fex                 string
+	Vlan                int64
+	MicroSegVlan        int64
+	Raw                 interface{}
 }
 
 func tableNDOEpgStaticPort() *plugin.Table {
@@ -39,11 +43,31 @@ func tableNDOEpgStaticPort() *plugin.Table {
 			Hydrate: listSchemaSiteAnpEpgStaticPorts,
 			KeyColumns: []*plugin.KeyColumn{
 				{
-					Name:    "id",
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "site_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "anp_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "epg_name",
 					Require: plugin.Optional,
 				},
 			},
 		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaSiteAnpEpgStaticPort,
+		},
 		Columns: []*plugin.Column{
 			{
 				Name:        "id",
@@ -90,12 +114,39 @@ func tableNDOEpgStaticPort() *plugin.Table {
 			},
 			{
 				Name:        "leaf",
-				Description: "(Required) The leaf of the static port.",
+				Description: "(Required) The leaf of the static port. Both leafs of the pair joined by a dash for a vpc, e.g. 101-102.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "leaf_a",
+				Description: "The leaf of a port or dpc, or the first leaf of a vpc pair.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "leaf_b",
+				Description: "The second leaf of a vpc pair. Empty for port and dpc.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "fex_id",
+				Description: "The FEX the port is on, e.g. 111, or 111-112 for a vpc to a FEX pair. Empty when the port is on the leaf itself.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FexId"),
+			},
+			{
+				Name:        "fex",
+				Description: "(Deprecated) Use fex_id instead. Kept so existing queries keep working, holds the same value.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FexId"),
+			},
 			{
 				Name:        "path",
-				Description: "(Required) The path of the static port.",
+				Description: "(Required) The path of the static port. The interface, e.g. eth1/1 or eth1/1/2 for a breakout port, or the policy group name for a vpc and dpc.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "path_dn",
+				Description: "The full ACI path DN of the static port, e.g. topology/pod-1/protpaths-101-102/pathep-[vpc_pg].",
 				Type:        proto.ColumnType_STRING,
 			},
 			{
@@ -111,17 +162,17 @@ func tableNDOEpgStaticPort() *plugin.Table {
 			{
 				Name:        "vlan",
 				Description: "(Required) The port encap VLAN id of the static port.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_INT,
 			},
 			{
 				Name:        "micro_seg_vlan",
 				Description: "(Optional) The microsegmentation VLAN id of the static port.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_INT,
 			},
 			{
-				Name:        "fex",
-				Description: "(Optional) Fex-id to be used. This parameter will work only with the path_type as port.",
-				Type:        proto.ColumnType_STRING,
+				Name:        "raw",
+				Description: "The full static port object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
 			},
 		},
 	}
@@ -134,73 +185,98 @@ func listSchemaSiteAnpEpgStaticPorts(ctx context.Context, d *plugin.QueryData, h
 		return nil, fmt.Errorf("Error connecting to ND: %v", err)
 	}
 
-	log.Printf("[DEBUG] Calling API: list-identity")
-	dnUrl := "/api/v1/schemas/list-identity"
-	identityList, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
+	err = forEachSiteEpg(ctx, d, ndoclient, func(schemaId string, cursite *container.Container, epgRef *ref.ObjectRef, curepg *container.Container) error {
+		staticportlist, err := curepg.S("staticPorts").Children()
+		if err != nil {
+			return fmt.Errorf("Error getting port list: %v", err)
+		}
+
+		for _, curport := range staticportlist {
+			portobj := buildSchemaSiteAnpEpgStaticPort(schemaId, cursite, epgRef, curport)
+			log.Printf("[TRACE] Record object: %v ", portobj)
+			d.StreamListItem(ctx, portobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaSiteAnpEpgStaticPort(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "site", "template", "anp", "epg", "staticPortPod", "staticPortLeaf", "pathType", "fex", "path")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
 
+	ndoclient, err := connect(ctx, d)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting Identity List: %v\nURL: %v", err, dnUrl)
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
 	}
 
-	log.Printf("[TRACE] Indentity List: %v", identityList)
-	schemaobjlist, err := identityList.S("schemas").Children()
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
 	if err != nil {
-		return nil, fmt.Errorf("Error getting Schema List: %v", err)
+		return nil, err
 	}
 
-	for _, curschema := range schemaobjlist {
-		dnUrl := "/api/v1/schemas/" + client.StripQuotes(curschema.S("id").String())
-		schemaDetails, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
+	cursite, epgRef, curepg := findSiteEpg(schemaDetails, idParts[1], idParts[2], idParts[3], idParts[4])
+	if curepg == nil {
+		return nil, nil
+	}
 
-		sitelist, err := schemaDetails.S("sites").Children()
-		if err != nil {
-			return nil, fmt.Errorf("Error getting site list: %v", err)
+	staticportlist, err := curepg.S("staticPorts").Children()
+	if err != nil {
+		return nil, nil
+	}
+
+	// the port itself is identified by its path, so rebuild the ids of the ports
+	// of the EPG and return the one that matches
+	for _, curport := range staticportlist {
+		portobj := buildSchemaSiteAnpEpgStaticPort(idParts[0], cursite, epgRef, curport)
+		if portobj.Id == id {
+			return portobj, nil
 		}
+	}
 
-		for _, cursite := range sitelist {
-			anpobjlist, err := cursite.S("anps").Children()
-			if err != nil {
-				return nil, fmt.Errorf("Error getting anp list: %v", err)
-			}
+	return nil, nil
+}
 
-			for _, curanp := range anpobjlist {
-				epgobjlist, err := curanp.S("epgs").Children()
-				if err != nil {
-					return nil, fmt.Errorf("Error getting epg list: %v", err)
-				}
-
-				for _, curepg := range epgobjlist {
-					staticportlist, err := curepg.S("staticPorts").Children()
-					if err != nil {
-						return nil, fmt.Errorf("Error getting port list: %v", err)
-					}
-
-					for _, curport := range staticportlist {
-						portobj := &SchemaSiteAnpEpgStaticPort{}
-						portobj.SchemaId = client.StripQuotes(curschema.S("id").String())
-						portobj.SiteId = client.StripQuotes(cursite.S("siteId").String())
-						portobj.TemplateName = client.StripQuotes(cursite.S("templateName").String())
-						epgPathInfo := strings.Split(client.StripQuotes(curepg.S("epgRef").String()), "/")
-						portobj.AnpName = epgPathInfo[6]
-						portobj.EpgName = epgPathInfo[8]
-						portobj.PathType = client.StripQuotes(curport.S("type").String())
-						portobj.DeploymentImmediacy = client.StripQuotes(curport.S("deploymentImmediacy").String())
-						portPathInfo := strings.Split(client.StripQuotes(curport.S("path").String()), "/")
-						portobj.Pod = portPathInfo[1]
-						portobj.Leaf = portPathInfo[2][10:len(portPathInfo[2])]
-						log.Printf("[TRACE] path: %s: length: %v len-1: %v value: %v", portPathInfo[3], len(portPathInfo[3]), len(portPathInfo[3])-1, portPathInfo[3][8:len(portPathInfo[3])-1])
-						portobj.Path = portPathInfo[3]
-						portobj.Path = portobj.Path[8 : len(portPathInfo[3])-1]
-						portobj.Vlan = client.StripQuotes(curport.S("portEncapVlan").String())
-						portobj.Mode = client.StripQuotes(curport.S("mode").String())
-						portobj.Id = portobj.SchemaId + "/site/" + portobj.SiteId + "/template/" + portobj.TemplateName + "/anp/" + portobj.AnpName + "/epg/" + portobj.EpgName + "/staticPortPod/" + portobj.Pod + "/staticPortLeaf/" + portobj.Leaf + "/pathType/" + portobj.PathType + "/fex/" + portobj.Leaf + "/path/" + portobj.Path
-						log.Printf("[TRACE] Record object: %s ", portobj)
-						d.StreamListItem(ctx, portobj)
-					}
-				}
+func buildSchemaSiteAnpEpgStaticPort(schemaId string, cursite *container.Container, epgRef *ref.ObjectRef, curport *container.Container) *SchemaSiteAnpEpgStaticPort {
+	portobj := &SchemaSiteAnpEpgStaticPort{}
+	portobj.SchemaId = schemaId
+	portobj.SiteId = cursite.Str("siteId")
+	portobj.TemplateName = cursite.Str("templateName")
+	portobj.AnpName = epgRef.NameOf("anps")
+	portobj.EpgName = epgRef.Name()
+	portobj.PathType = curport.Str("type")
+	portobj.DeploymentImmediacy = curport.Str("deploymentImmediacy")
+	portobj.PathDn = curport.Str("path")
+	path, err := ref.ParsePath(portobj.PathDn)
+	if err != nil {
+		log.Printf("[WARN] Static port of EPG %s: %v", portobj.EpgName, err)
+	} else {
+		portobj.Pod = path.Pod
+		portobj.Leaf = path.Leaf
+		portobj.LeafA = path.LeafA
+		portobj.LeafB = path.LeafB
+		portobj.FexId = path.FexId
+		portobj.Path = path.Interface
+		// older schemas do not record the type, a dpc is indistinguishable from a port by its DN
+		if portobj.PathType == "" {
+			if path.Protected {
+				portobj.PathType = "vpc"
+			} else {
+				portobj.PathType = "port"
 			}
 		}
 	}
-
-	return nil, nil
+	portobj.Vlan = curport.Int("portEncapVlan")
+	portobj.MicroSegVlan = curport.Int("microSegVlan")
+	portobj.Mode = curport.Str("mode")
+	portobj.Raw = curport.Data()
+	portobj.Id = portobj.SchemaId + "/site/" + portobj.SiteId + "/template/" + portobj.TemplateName + "/anp/" + portobj.AnpName + "/epg/" + portobj.EpgName + "/staticPortPod/" + portobj.Pod + "/staticPortLeaf/" + portobj.Leaf + "/pathType/" + portobj.PathType + "/fex/" + portobj.FexId + "/path/" + portobj.Path
+	return portobj
 }
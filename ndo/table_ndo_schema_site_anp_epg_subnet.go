@@ -0,0 +1,221 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaSiteAnpEpgSubnet struct {
+	Id               string
+	SchemaId         string
+	SiteId           string
+	TemplateName     string
+	AnpName          string
+	EpgName          string
+	GatewayIp        string
+	SubnetCidr       string
+	Scope            string
+	Shared           bool
+	NoDefaultGateway bool
+	Querier          bool
+	Description      string
+	Raw              interface{}
+}
+
+func tableNDOSchemaSiteAnpEpgSubnet() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_site_anp_epg_subnet",
+		Description: "NDO Schema-Site-ANP-EPG Subnets",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaSiteAnpEpgSubnet,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "site_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "anp_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "epg_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaSiteAnpEpgSubnet,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID under which the subnet is deployed.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "site_id",
+				Description: "(Required) SiteID where the subnet is configured.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SiteId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template deployed to the site.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "anp_name",
+				Description: "(Required) Name of the Application Network Profile.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "epg_name",
+				Description: "(Required) Name of the Endpoint Group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "gateway_ip",
+				Description: "(Required) The gateway IP and mask of the subnet as configured, e.g. 10.0.0.1/24.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "subnet_cidr",
+				Description: "(Required) The network of the subnet, e.g. 10.0.0.0/24 for gateway 10.0.0.1/24.",
+				Type:        proto.ColumnType_CIDR,
+			},
+			{
+				Name:        "scope",
+				Description: "(Optional) The scope of the subnet. Allowed values are private and public. Default to private.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "shared",
+				Description: "(Optional) Whether the subnet is shared between VRFs. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Shared"),
+			},
+			{
+				Name:        "no_default_gateway",
+				Description: "(Optional) Whether the subnet has no default SVI gateway. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("NoDefaultGateway"),
+			},
+			{
+				Name:        "querier",
+				Description: "(Optional) Whether the subnet is an IGMP querier. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Querier"),
+			},
+			{
+				Name:        "description",
+				Description: "(Optional) The description of the subnet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "raw",
+				Description: "The full site subnet object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaSiteAnpEpgSubnet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachSiteEpg(ctx, d, ndoclient, func(schemaId string, cursite *container.Container, epgRef *ref.ObjectRef, curepg *container.Container) error {
+		subnetlist, err := curepg.S("subnets").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, cursubnet := range subnetlist {
+			subnetobj := buildSchemaSiteAnpEpgSubnet(schemaId, cursite, epgRef, cursubnet)
+			log.Printf("[TRACE] Record object: %v ", subnetobj)
+			d.StreamListItem(ctx, subnetobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaSiteAnpEpgSubnet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "site", "template", "anp", "epg", "subnet")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	cursite, epgRef, curepg := findSiteEpg(schemaDetails, idParts[1], idParts[2], idParts[3], idParts[4])
+	if curepg == nil {
+		return nil, nil
+	}
+
+	cursubnet := findByName(curepg.S("subnets"), "ip", idParts[5])
+	if cursubnet == nil {
+		return nil, nil
+	}
+
+	return buildSchemaSiteAnpEpgSubnet(idParts[0], cursite, epgRef, cursubnet), nil
+}
+
+func buildSchemaSiteAnpEpgSubnet(schemaId string, cursite *container.Container, epgRef *ref.ObjectRef, cursubnet *container.Container) *SchemaSiteAnpEpgSubnet {
+	subnetobj := &SchemaSiteAnpEpgSubnet{}
+	subnetobj.SchemaId = schemaId
+	subnetobj.SiteId = cursite.Str("siteId")
+	subnetobj.TemplateName = cursite.Str("templateName")
+	subnetobj.AnpName = epgRef.NameOf("anps")
+	subnetobj.EpgName = epgRef.Name()
+	subnetobj.GatewayIp = cursubnet.Str("ip")
+	subnetobj.SubnetCidr = subnetCidr(subnetobj.GatewayIp)
+	subnetobj.Scope = cursubnet.Str("scope")
+	subnetobj.Shared = cursubnet.Bool("shared")
+	subnetobj.NoDefaultGateway = cursubnet.Bool("noDefaultGateway")
+	subnetobj.Querier = cursubnet.Bool("querier")
+	subnetobj.Description = cursubnet.Str("description")
+	subnetobj.Raw = cursubnet.Data()
+	subnetobj.Id = subnetobj.SchemaId + "/site/" + subnetobj.SiteId + "/template/" + subnetobj.TemplateName + "/anp/" + subnetobj.AnpName + "/epg/" + subnetobj.EpgName + "/subnet/" + subnetobj.GatewayIp
+	return subnetobj
+}
@@ -0,0 +1,193 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaSiteBd struct {
+	Id               string
+	SchemaId         string
+	SiteId           string
+	TemplateName     string
+	Name             string
+	BdSchemaId       string
+	BdTemplateName   string
+	HostBasedRouting bool
+	Mac              string
+	L3outs           []string
+	Subnets          interface{}
+	Raw              interface{}
+}
+
+func tableNDOSchemaSiteBd() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_site_bd",
+		Description: "NDO Schema-Site-Bd",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaSiteBd,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "site_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaSiteBd,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID under which the Bridge Domain is deployed to the site.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "site_id",
+				Description: "(Required) SiteID where the Bridge Domain is deployed.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SiteId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template deployed to the site.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "(Required) Name of the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "bd_schema_id",
+				Description: "(Optional) The schemaID that defines the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("BdSchemaId"),
+			},
+			{
+				Name:        "bd_template_name",
+				Description: "(Optional) The template that defines the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "host_based_routing",
+				Description: "(Optional) Whether host routes of the Bridge Domain are advertised at this site. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("HostBasedRouting"),
+			},
+			{
+				Name:        "mac",
+				Description: "(Optional) The MAC address of the Bridge Domain at this site.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "l3outs",
+				Description: "(Optional) Names of the site L3Outs the Bridge Domain subnets are advertised out.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "subnets",
+				Description: "(Optional) The subnets configured for the Bridge Domain only at this site, see ndo_schema_site_bd_subnet.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "raw",
+				Description: "The full site Bridge Domain object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaSiteBd(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachSiteBd(ctx, d, ndoclient, "name", func(schemaId string, cursite *container.Container, bdRef *ref.ObjectRef, curbd *container.Container) error {
+		bdobj := buildSchemaSiteBd(schemaId, cursite, bdRef, curbd)
+		log.Printf("[TRACE] Record object: %v ", bdobj)
+		d.StreamListItem(ctx, bdobj)
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaSiteBd(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "site", "template", "bd")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	cursite, bdRef, curbd := findSiteBd(schemaDetails, idParts[1], idParts[2], idParts[3])
+	if curbd == nil {
+		return nil, nil
+	}
+
+	return buildSchemaSiteBd(idParts[0], cursite, bdRef, curbd), nil
+}
+
+func buildSchemaSiteBd(schemaId string, cursite *container.Container, bdRef *ref.ObjectRef, curbd *container.Container) *SchemaSiteBd {
+	bdobj := &SchemaSiteBd{}
+	bdobj.SchemaId = schemaId
+	bdobj.SiteId = cursite.Str("siteId")
+	bdobj.TemplateName = cursite.Str("templateName")
+	bdobj.Name = bdRef.Name()
+	bdobj.BdSchemaId = bdRef.SchemaId
+	bdobj.BdTemplateName = bdRef.TemplateName
+	bdobj.HostBasedRouting = curbd.Bool("hostBasedRouting")
+	bdobj.Mac = curbd.Str("mac")
+	for _, l3out := range siteBdL3outs(curbd) {
+		bdobj.L3outs = append(bdobj.L3outs, l3out.name)
+	}
+	if curbd.Exists("subnets") {
+		bdobj.Subnets = curbd.S("subnets").Data()
+	}
+	bdobj.Raw = curbd.Data()
+	bdobj.Id = bdobj.SchemaId + "/site/" + bdobj.SiteId + "/template/" + bdobj.TemplateName + "/bd/" + bdobj.Name
+	return bdobj
+}
@@ -0,0 +1,184 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaSiteBdL3out struct {
+	Id                string
+	SchemaId          string
+	SiteId            string
+	TemplateName      string
+	BdName            string
+	L3outName         string
+	L3outSchemaId     string
+	L3outTemplateName string
+	Raw               interface{}
+}
+
+func tableNDOSchemaSiteBdL3out() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_site_bd_l3out",
+		Description: "NDO Schema-Site-Bd L3Outs",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaSiteBdL3out,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "site_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "bd_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "l3out_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaSiteBdL3out,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID of the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "site_id",
+				Description: "(Required) SiteID where the Bridge Domain is advertised out the L3Out.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SiteId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template of the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "bd_name",
+				Description: "(Required) Name of the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "l3out_name",
+				Description: "(Required) Name of the site L3Out the Bridge Domain subnets are advertised out.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "l3out_schema_id",
+				Description: "(Optional) SchemaID where the L3Out is defined. Only set by NDO versions that reference the L3Out through l3OutRefs.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("L3outSchemaId"),
+			},
+			{
+				Name:        "l3out_template_name",
+				Description: "(Optional) Template where the L3Out is defined. Only set by NDO versions that reference the L3Out through l3OutRefs.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "raw",
+				Description: "The full site Bridge Domain object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaSiteBdL3out(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachSiteBd(ctx, d, ndoclient, "bd_name", func(schemaId string, cursite *container.Container, bdRef *ref.ObjectRef, curbd *container.Container) error {
+		for _, l3out := range siteBdL3outs(curbd) {
+			if !matchesQual(d, "l3out_name", l3out.name) {
+				continue
+			}
+
+			l3outobj := buildSchemaSiteBdL3out(schemaId, cursite, bdRef, curbd, l3out)
+			log.Printf("[TRACE] Record object: %v ", l3outobj)
+			d.StreamListItem(ctx, l3outobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaSiteBdL3out(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "site", "template", "bd", "l3out")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	cursite, bdRef, curbd := findSiteBd(schemaDetails, idParts[1], idParts[2], idParts[3])
+	if curbd == nil {
+		return nil, nil
+	}
+
+	for _, l3out := range siteBdL3outs(curbd) {
+		if l3out.name == idParts[4] {
+			return buildSchemaSiteBdL3out(idParts[0], cursite, bdRef, curbd, l3out), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func buildSchemaSiteBdL3out(schemaId string, cursite *container.Container, bdRef *ref.ObjectRef, curbd *container.Container, l3out siteBdL3out) *SchemaSiteBdL3out {
+	l3outobj := &SchemaSiteBdL3out{}
+	l3outobj.SchemaId = schemaId
+	l3outobj.SiteId = cursite.Str("siteId")
+	l3outobj.TemplateName = cursite.Str("templateName")
+	l3outobj.BdName = bdRef.Name()
+	l3outobj.L3outName = l3out.name
+	l3outobj.L3outSchemaId = l3out.schemaId
+	l3outobj.L3outTemplateName = l3out.templateName
+	l3outobj.Raw = curbd.Data()
+	l3outobj.Id = l3outobj.SchemaId + "/site/" + l3outobj.SiteId + "/template/" + l3outobj.TemplateName + "/bd/" + l3outobj.BdName + "/l3out/" + l3outobj.L3outName
+	return l3outobj
+}
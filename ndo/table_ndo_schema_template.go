@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"log"
 
-	"steampipe-plugin-ndo/client"
+	"steampipe-plugin-ndo/container"
 
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
@@ -28,11 +28,19 @@ func tableNDOSchemaTemplate() *plugin.Table {
 			Hydrate: listSchemaTemplate,
 			KeyColumns: []*plugin.KeyColumn{
 				{
-					Name:    "id",
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "name",
 					Require: plugin.Optional,
 				},
 			},
 		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplate,
+		},
 		Columns: []*plugin.Column{
 			{
 				Name:        "id",
@@ -62,6 +70,13 @@ func tableNDOSchemaTemplate() *plugin.Table {
 				Description: "(Required) Display name of the Template to be deployed on the site.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "raw",
+				Description: "The full template object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getSchemaTemplateRaw,
+				Transform:   transform.FromValue(),
+			},
 		},
 	}
 }
@@ -73,9 +88,8 @@ func listSchemaTemplate(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 		return nil, fmt.Errorf("Error connecting to ND: %v", err)
 	}
 
-	log.Printf("[DEBUG] Calling API: list-identity")
 	dnUrl := "/api/v1/schemas/list-identity"
-	identityList, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
+	identityList, err := getSchemaIdentityList(ctx, d, ndoclient)
 
 	if err != nil {
 		return nil, fmt.Errorf("Error getting Identity List: %v\nURL: %v", err, dnUrl)
@@ -88,6 +102,10 @@ func listSchemaTemplate(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	}
 
 	for _, curschema := range identityListChildren {
+		if !matchesQual(d, "schema_id", curschema.Str("id")) {
+			continue
+		}
+
 		log.Printf("[TRACE] Processing Schema: %v", curschema)
 		templateList, err := curschema.S("templates").Children()
 		if err != nil {
@@ -95,15 +113,14 @@ func listSchemaTemplate(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 		}
 
 		for _, curtemplate := range templateList {
+			if !matchesQual(d, "name", curtemplate.Str("name")) {
+				continue
+			}
+
 			log.Printf("[TRACE] Processing Template: %v", curtemplate)
 			log.Printf("[TRACE] inside schema: %v", curschema)
 
-			schemaTemplateObj := SchemaTemplate{}
-			schemaTemplateObj.Id = client.StripQuotes(curschema.S("id").String()) + "/template/" + client.StripQuotes(curtemplate.S("name").String())
-			schemaTemplateObj.SchemaId = client.StripQuotes(curschema.S("id").String())
-			schemaTemplateObj.TenantId = client.StripQuotes(curtemplate.S("tenantId").String())
-			schemaTemplateObj.Name = client.StripQuotes(curtemplate.S("name").String())
-			schemaTemplateObj.DisplayName = client.StripQuotes(curtemplate.S("displayName").String())
+			schemaTemplateObj := buildSchemaTemplate(curschema.Str("id"), curtemplate)
 
 			log.Printf("[TRACE] Built Schema-Template Object: %v", schemaTemplateObj)
 
@@ -113,3 +130,63 @@ func listSchemaTemplate(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 
 	return nil, nil
 }
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplate(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemplate := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemplate == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplate(idParts[0], curtemplate), nil
+}
+
+// the identity list only carries the template names, so the template object
+// itself is read from the schema document
+func getSchemaTemplateRaw(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	schemaTemplateObj := h.Item.(*SchemaTemplate)
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, schemaTemplateObj.SchemaId)
+	if err != nil {
+		return nil, err
+	}
+
+	curtemplate := findByName(schemaDetails.S("templates"), "name", schemaTemplateObj.Name)
+	if curtemplate == nil {
+		return nil, nil
+	}
+
+	return curtemplate.Data(), nil
+}
+
+func buildSchemaTemplate(schemaId string, curtemplate *container.Container) *SchemaTemplate {
+	schemaTemplateObj := &SchemaTemplate{}
+	schemaTemplateObj.Id = schemaId + "/template/" + curtemplate.Str("name")
+	schemaTemplateObj.SchemaId = schemaId
+	schemaTemplateObj.TenantId = curtemplate.Str("tenantId")
+	schemaTemplateObj.Name = curtemplate.Str("name")
+	schemaTemplateObj.DisplayName = curtemplate.Str("displayName")
+	return schemaTemplateObj
+}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"log"
 
-	"steampipe-plugin-ndo/client"
+	"steampipe-plugin-ndo/container"
 
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
@@ -18,6 +18,7 @@ type SchemaTemplateAnp struct {
 	Name        string
 	Template    string
 	DisplayName string
+	Raw         interface{}
 }
 
 func tableNDOSchemaTemplateAnp() *plugin.Table {
@@ -28,11 +29,23 @@ func tableNDOSchemaTemplateAnp() *plugin.Table {
 			Hydrate: listSchemaTemplateAnp,
 			KeyColumns: []*plugin.KeyColumn{
 				{
-					Name:    "id",
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "name",
 					Require: plugin.Optional,
 				},
 			},
 		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateAnp,
+		},
 		Columns: []*plugin.Column{
 			{
 				Name:        "id",
@@ -61,6 +74,11 @@ func tableNDOSchemaTemplateAnp() *plugin.Table {
 				Description: "(Required) The name as displayed on the MSO web interface.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "raw",
+				Description: "The full ANP object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
 		},
 	}
 }
@@ -72,47 +90,78 @@ func listSchemaTemplateAnp(ctx context.Context, d *plugin.QueryData, h *plugin.H
 		return nil, fmt.Errorf("Error connecting to ND: %v", err)
 	}
 
-	log.Printf("[DEBUG] Calling API: list-identity")
-	dnUrl := "/api/v1/schemas/list-identity"
-	identityList, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
-
-	if err != nil {
-		return nil, fmt.Errorf("Error getting Identity List: %v\nURL: %v", err, dnUrl)
-	}
-
-	log.Printf("[TRACE] Indentity List: %v", identityList)
-	schemaobjlist, err := identityList.S("schemas").Children()
-	if err != nil {
-		return nil, fmt.Errorf("Error getting Schema List: %v", err)
-	}
-
-	for _, curschema := range schemaobjlist {
-		dnUrl := "/api/v1/schemas/" + client.StripQuotes(curschema.S("id").String())
-		schemaDetails, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
-
+	err = forEachSchema(ctx, d, ndoclient, func(schemaId string, schemaDetails *container.Container) error {
 		templatelist, err := schemaDetails.S("templates").Children()
 		if err != nil {
-			return nil, fmt.Errorf("Error getting template list: %v", err)
+			return fmt.Errorf("Error getting template list: %v", err)
 		}
 
 		for _, curtemp := range templatelist {
+			if !matchesQual(d, "template", curtemp.Str("name")) {
+				continue
+			}
+
 			anpobjlist, err := curtemp.S("anps").Children()
 			if err != nil {
-				return nil, fmt.Errorf("Error getting anp list: %v", err)
+				return fmt.Errorf("Error getting anp list: %v", err)
 			}
 
 			for _, curanp := range anpobjlist {
-				anpobj := &SchemaTemplateAnp{}
-				anpobj.SchemaId = client.StripQuotes(curschema.S("id").String())
-				anpobj.Name = client.StripQuotes(curanp.S("name").String())
-				anpobj.Template = client.StripQuotes(curtemp.S("name").String())
-				anpobj.DisplayName = client.StripQuotes(curanp.S("displayName").String())
-				anpobj.Id = anpobj.SchemaId + "/template/" + anpobj.Template + "/anp/" + anpobj.Name
-				log.Printf("[TRACE] Record object: %s ", anpobj)
+				if !matchesQual(d, "name", curanp.Str("name")) {
+					continue
+				}
+
+				anpobj := buildSchemaTemplateAnp(schemaId, curtemp, curanp)
+				log.Printf("[TRACE] Record object: %v ", anpobj)
 				d.StreamListItem(ctx, anpobj)
 			}
 		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateAnp(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "anp")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
 	}
 
-	return nil, nil
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curanp := findByName(curtemp.S("anps"), "name", idParts[2])
+	if curanp == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateAnp(idParts[0], curtemp, curanp), nil
+}
+
+func buildSchemaTemplateAnp(schemaId string, curtemp *container.Container, curanp *container.Container) *SchemaTemplateAnp {
+	anpobj := &SchemaTemplateAnp{}
+	anpobj.SchemaId = schemaId
+	anpobj.Name = curanp.Str("name")
+	anpobj.Template = curtemp.Str("name")
+	anpobj.DisplayName = curanp.Str("displayName")
+	anpobj.Raw = curanp.Data()
+	anpobj.Id = anpobj.SchemaId + "/template/" + anpobj.Template + "/anp/" + anpobj.Name
+	return anpobj
 }
@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 
-	"steampipe-plugin-ndo/client"
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
 
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
@@ -26,11 +26,12 @@ type SchemaTemplateAnpEpg struct {
 	VrfSchemaId              string
 	VrfTemplateName          string
 	DisplayName              string
-	UsegEpg                  string
+	UsegEpg                  bool
 	IntraEpg                 string
-	IntersiteMulticastSource string
-	ProxyArp                 string
-	PreferredGroup           string
+	IntersiteMulticastSource bool
+	ProxyArp                 bool
+	PreferredGroup           bool
+	Raw                      interface{}
 }
 
 func tableNDOSchemaTemplateAnpEpg() *plugin.Table {
@@ -41,11 +42,27 @@ func tableNDOSchemaTemplateAnpEpg() *plugin.Table {
 			Hydrate: listSchemaTemplateAnpEpg,
 			KeyColumns: []*plugin.KeyColumn{
 				{
-					Name:    "id",
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "anp_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "name",
 					Require: plugin.Optional,
 				},
 			},
 		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateAnpEpg,
+		},
 		Columns: []*plugin.Column{
 			{
 				Name:        "id",
@@ -114,7 +131,8 @@ func tableNDOSchemaTemplateAnpEpg() *plugin.Table {
 			{
 				Name:        "useg_epg",
 				Description: "(Optional) Boolean flag to enable or disable whether this is a USEG EPG. Default value is set to false.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("UsegEpg"),
 			},
 			{
 				Name:        "intra_epg",
@@ -124,17 +142,25 @@ func tableNDOSchemaTemplateAnpEpg() *plugin.Table {
 			{
 				Name:        "intersite_multicast_source",
 				Description: "(Optional) Whether intersite multicast source is enabled. Default to false.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("IntersiteMulticastSource"),
 			},
 			{
 				Name:        "proxy_arp",
 				Description: "(Optional) Whether to enable Proxy ARP or not. (For Forwarding control) Default to false.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ProxyArp"),
 			},
 			{
 				Name:        "preferred_group",
 				Description: "(Optional) Boolean flag to enable or disable whether this EPG is added to preferred group. Default value is set to false.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("PreferredGroup"),
+			},
+			{
+				Name:        "raw",
+				Description: "The full EPG object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
 			},
 		},
 	}
@@ -147,71 +173,86 @@ func listSchemaTemplateAnpEpg(ctx context.Context, d *plugin.QueryData, h *plugi
 		return nil, fmt.Errorf("Error connecting to ND: %v", err)
 	}
 
-	log.Printf("[DEBUG] Calling API: list-identity")
-	dnUrl := "/api/v1/schemas/list-identity"
-	identityList, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
+	err = forEachTemplateEpg(ctx, d, ndoclient, "name", func(schemaId string, curtemp *container.Container, curanp *container.Container, curepg *container.Container) error {
+		epgobj := buildSchemaTemplateAnpEpg(schemaId, curtemp, curanp, curepg)
+		log.Printf("[TRACE] Record object: %v ", epgobj)
+		d.StreamListItem(ctx, epgobj)
+		return nil
+	})
 
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateAnpEpg(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "anp", "epg")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting Identity List: %v\nURL: %v", err, dnUrl)
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
 	}
 
-	log.Printf("[TRACE] Indentity List: %v", identityList)
-	schemaobjlist, err := identityList.S("schemas").Children()
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
 	if err != nil {
-		return nil, fmt.Errorf("Error getting Schema List: %v", err)
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
 	}
 
-	for _, curschema := range schemaobjlist {
-		dnUrl := "/api/v1/schemas/" + client.StripQuotes(curschema.S("id").String())
-		schemaDetails, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
+	curanp := findByName(curtemp.S("anps"), "name", idParts[2])
+	if curanp == nil {
+		return nil, nil
+	}
+
+	curepg := findByName(curanp.S("epgs"), "name", idParts[3])
+	if curepg == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateAnpEpg(idParts[0], curtemp, curanp, curepg), nil
+}
 
-		teamplatelist, err := schemaDetails.S("templates").Children()
+func buildSchemaTemplateAnpEpg(schemaId string, curtemp *container.Container, curanp *container.Container, curepg *container.Container) *SchemaTemplateAnpEpg {
+	epgobj := &SchemaTemplateAnpEpg{}
+	epgobj.SchemaId = schemaId
+	epgobj.TemplateName = curtemp.Str("name")
+	epgobj.AnpName = curanp.Str("name")
+	epgobj.Name = curepg.Str("name")
+	if bdRef := curepg.Str("bdRef"); bdRef != "" {
+		bd, err := ref.ParseKind(bdRef, "bds")
 		if err != nil {
-			return nil, fmt.Errorf("Error getting site list: %v", err)
+			log.Printf("[WARN] EPG %s: %v", epgobj.Name, err)
+		} else {
+			epgobj.BdName = bd.Name()
+			epgobj.BdSchemaId = bd.SchemaId
+			epgobj.BdTemplateName = bd.TemplateName
 		}
-
-		for _, curtemp := range teamplatelist {
-			anpobjlist, err := curtemp.S("anps").Children()
-			if err != nil {
-				return nil, fmt.Errorf("Error getting anp list: %v", err)
-			}
-
-			for _, curanp := range anpobjlist {
-				epgobjlist, err := curanp.S("epgs").Children()
-				if err != nil {
-					return nil, fmt.Errorf("Error getting epg list: %v", err)
-				}
-
-				for _, curepg := range epgobjlist {
-					epgobj := &SchemaTemplateAnpEpg{}
-					epgobj.SchemaId = client.StripQuotes(curschema.S("id").String())
-					epgobj.TemplateName = client.StripQuotes(curtemp.S("name").String())
-					epgobj.AnpName = client.StripQuotes(curanp.S("name").String())
-					epgobj.Name = client.StripQuotes(curepg.S("name").String())
-					if client.StripQuotes(curepg.S("bdRef").String()) != "" {
-						bdInfo := strings.Split(client.StripQuotes(curepg.S("bdRef").String()), "/")
-						epgobj.BdName = bdInfo[6]
-						epgobj.BdSchemaId = bdInfo[2]
-						epgobj.BdTemplateName = bdInfo[4]
-					}
-					if client.StripQuotes(curepg.S("vrfRef").String()) != "" {
-						vrfInfo := strings.Split(client.StripQuotes(curepg.S("vrfRef").String()), "/")
-						epgobj.VrfName = vrfInfo[5]
-						epgobj.VrfSchemaId = vrfInfo[1]
-						epgobj.VrfTemplateName = vrfInfo[3]
-					}
-					epgobj.DisplayName = client.StripQuotes(curepg.S("displayName").String())
-					epgobj.UsegEpg = client.StripQuotes(curepg.S("uSegEpg").String())
-					epgobj.IntraEpg = client.StripQuotes(curepg.S("intraEpg").String())
-					epgobj.IntersiteMulticastSource = client.StripQuotes(curepg.S("mCastSource").String())
-					epgobj.ProxyArp = client.StripQuotes(curepg.S("proxyArp").String())
-					epgobj.PreferredGroup = client.StripQuotes(curepg.S("preferredGroup").String())
-					epgobj.Id = epgobj.SchemaId + "/template/" + epgobj.TemplateName + "/anp/" + epgobj.AnpName + "/epg/" + epgobj.Name
-					log.Printf("[TRACE] Record object: %s ", epgobj)
-					d.StreamListItem(ctx, epgobj)
-				}
-			}
+	}
+	if vrfRef := curepg.Str("vrfRef"); vrfRef != "" {
+		vrf, err := ref.ParseKind(vrfRef, "vrfs")
+		if err != nil {
+			log.Printf("[WARN] EPG %s: %v", epgobj.Name, err)
+		} else {
+			epgobj.VrfName = vrf.Name()
+			epgobj.VrfSchemaId = vrf.SchemaId
+			epgobj.VrfTemplateName = vrf.TemplateName
 		}
 	}
-	return nil, nil
+	epgobj.DisplayName = curepg.Str("displayName")
+	epgobj.UsegEpg = curepg.Bool("uSegEpg")
+	epgobj.IntraEpg = curepg.Str("intraEpg")
+	epgobj.IntersiteMulticastSource = curepg.Bool("mCastSource")
+	epgobj.ProxyArp = curepg.Bool("proxyArp")
+	epgobj.PreferredGroup = curepg.Bool("preferredGroup")
+	epgobj.Raw = curepg.Data()
+	epgobj.Id = epgobj.SchemaId + "/template/" + epgobj.TemplateName + "/anp/" + epgobj.AnpName + "/epg/" + epgobj.Name
+	return epgobj
 }
@@ -0,0 +1,220 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateAnpEpgContract struct {
+	Id                   string
+	SchemaId             string
+	TemplateName         string
+	AnpName              string
+	EpgName              string
+	RelationshipType     string
+	ContractSchemaId     string
+	ContractTemplateName string
+	ContractName         string
+	Raw                  interface{}
+}
+
+func tableNDOSchemaTemplateAnpEpgContract() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_anp_epg_contract",
+		Description: "NDO Schema-Template-Anp-Epg Contract Relationships",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateAnpEpgContract,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "anp_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "epg_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "relationship_type",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "contract_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateAnpEpgContract,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID of the Endpoint Group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template of the Endpoint Group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "anp_name",
+				Description: "(Required) Name of the Application Network Profile.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "epg_name",
+				Description: "(Required) Name of the Endpoint Group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "relationship_type",
+				Description: "(Required) Whether the Endpoint Group provides or consumes the contract. Allowed values are provider and consumer.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "contract_schema_id",
+				Description: "(Required) The schemaID that defines the contract.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ContractSchemaId"),
+			},
+			{
+				Name:        "contract_template_name",
+				Description: "(Required) The template that defines the contract.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "contract_name",
+				Description: "(Required) Name of the contract.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "raw",
+				Description: "The full contract relationship object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateAnpEpgContract(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplateEpg(ctx, d, ndoclient, "epg_name", func(schemaId string, curtemp *container.Container, curanp *container.Container, curepg *container.Container) error {
+		relationshiplist, err := curepg.S("contractRelationships").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, currelationship := range relationshiplist {
+			contractobj := buildSchemaTemplateAnpEpgContract(schemaId, curtemp, curanp, curepg, currelationship)
+			if !matchesQual(d, "relationship_type", contractobj.RelationshipType) || !matchesQual(d, "contract_name", contractobj.ContractName) {
+				continue
+			}
+
+			log.Printf("[TRACE] Record object: %v ", contractobj)
+			d.StreamListItem(ctx, contractobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateAnpEpgContract(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "anp", "epg", "relationshipType", "contractSchema", "contractTemplate", "contract")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curanp := findByName(curtemp.S("anps"), "name", idParts[2])
+	if curanp == nil {
+		return nil, nil
+	}
+
+	curepg := findByName(curanp.S("epgs"), "name", idParts[3])
+	if curepg == nil {
+		return nil, nil
+	}
+
+	relationshiplist, err := curepg.S("contractRelationships").Children()
+	if err != nil {
+		return nil, nil
+	}
+
+	for _, currelationship := range relationshiplist {
+		contractobj := buildSchemaTemplateAnpEpgContract(idParts[0], curtemp, curanp, curepg, currelationship)
+		if contractobj.Id == id {
+			return contractobj, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func buildSchemaTemplateAnpEpgContract(schemaId string, curtemp *container.Container, curanp *container.Container, curepg *container.Container, currelationship *container.Container) *SchemaTemplateAnpEpgContract {
+	contractobj := &SchemaTemplateAnpEpgContract{}
+	contractobj.SchemaId = schemaId
+	contractobj.TemplateName = curtemp.Str("name")
+	contractobj.AnpName = curanp.Str("name")
+	contractobj.EpgName = curepg.Str("name")
+	contractobj.RelationshipType = currelationship.Str("relationshipType")
+	contract, err := ref.ParseKind(currelationship.Str("contractRef"), "contracts")
+	if err != nil {
+		log.Printf("[WARN] EPG %s: %v", contractobj.EpgName, err)
+	} else {
+		contractobj.ContractSchemaId = contract.SchemaId
+		contractobj.ContractTemplateName = contract.TemplateName
+		contractobj.ContractName = contract.Name()
+	}
+	contractobj.Raw = currelationship.Data()
+	contractobj.Id = contractobj.SchemaId + "/template/" + contractobj.TemplateName + "/anp/" + contractobj.AnpName + "/epg/" + contractobj.EpgName + "/relationshipType/" + contractobj.RelationshipType + "/contractSchema/" + contractobj.ContractSchemaId + "/contractTemplate/" + contractobj.ContractTemplateName + "/contract/" + contractobj.ContractName
+	return contractobj
+}
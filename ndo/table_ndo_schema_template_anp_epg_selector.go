@@ -0,0 +1,221 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateAnpEpgSelector struct {
+	Id              string
+	SchemaId        string
+	TemplateName    string
+	AnpName         string
+	EpgName         string
+	Name            string
+	ExpressionIndex *int
+	Key             string
+	Operator        string
+	Value           string
+	Expressions     interface{}
+	Raw             interface{}
+}
+
+func tableNDOSchemaTemplateAnpEpgSelector() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_anp_epg_selector",
+		Description: "NDO Schema-Template-Anp-Epg Selectors",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateAnpEpgSelector,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "anp_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "epg_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateAnpEpgSelector,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID of the Endpoint Group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template of the Endpoint Group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "anp_name",
+				Description: "(Required) Name of the Application Network Profile.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "epg_name",
+				Description: "(Required) Name of the Endpoint Group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "(Required) Name of the selector.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "expression_index",
+				Description: "(Optional) Position of the expression in the selector, starting at 0. A selector has a row per expression, or a single row with no expression if it has none.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ExpressionIndex"),
+			},
+			{
+				Name:        "key",
+				Description: "(Optional) The key of the selector expression, e.g. ipAddress.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "operator",
+				Description: "(Optional) The operator of the selector expression, e.g. equals.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "value",
+				Description: "(Optional) The value of the selector expression.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "expressions",
+				Description: "(Optional) All expressions of the selector, with their key, operator and value.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "raw",
+				Description: "The full selector object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateAnpEpgSelector(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplateEpg(ctx, d, ndoclient, "epg_name", func(schemaId string, curtemp *container.Container, curanp *container.Container, curepg *container.Container) error {
+		selectorlist, err := curepg.S("selectors").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, curselector := range selectorlist {
+			for _, index := range selectorExpressionIndexes(curselector) {
+				selectorobj := buildSchemaTemplateAnpEpgSelector(schemaId, curtemp, curanp, curepg, curselector, index)
+				log.Printf("[TRACE] Record object: %v ", selectorobj)
+				d.StreamListItem(ctx, selectorobj)
+			}
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateAnpEpgSelector(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "anp", "epg", "selector")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curanp := findByName(curtemp.S("anps"), "name", idParts[2])
+	if curanp == nil {
+		return nil, nil
+	}
+
+	curepg := findByName(curanp.S("epgs"), "name", idParts[3])
+	if curepg == nil {
+		return nil, nil
+	}
+
+	selectorName, index, err := parseSelectorId(idParts[4])
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	curselector := findByName(curepg.S("selectors"), "name", selectorName)
+	if curselector == nil || !hasSelectorRow(curselector, index) {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateAnpEpgSelector(idParts[0], curtemp, curanp, curepg, curselector, index), nil
+}
+
+func buildSchemaTemplateAnpEpgSelector(schemaId string, curtemp *container.Container, curanp *container.Container, curepg *container.Container, curselector *container.Container, index int) *SchemaTemplateAnpEpgSelector {
+	selectorobj := &SchemaTemplateAnpEpgSelector{}
+	selectorobj.SchemaId = schemaId
+	selectorobj.TemplateName = curtemp.Str("name")
+	selectorobj.AnpName = curanp.Str("name")
+	selectorobj.EpgName = curepg.Str("name")
+	selectorobj.Name = curselector.Str("name")
+	if curexpression := selectorExpressionAt(curselector, index); curexpression != nil {
+		selectorobj.ExpressionIndex = &index
+		selectorobj.Key = curexpression.Str("key")
+		selectorobj.Operator = curexpression.Str("operator")
+		selectorobj.Value = curexpression.Str("value")
+	}
+	if curselector.Exists("expressions") {
+		selectorobj.Expressions = curselector.S("expressions").Data()
+	}
+	selectorobj.Raw = curselector.Data()
+	selectorobj.Id = selectorobj.SchemaId + "/template/" + selectorobj.TemplateName + "/anp/" + selectorobj.AnpName + "/epg/" + selectorobj.EpgName + "/selector/" + selectorSuffix(selectorobj.Name, selectorobj.ExpressionIndex)
+	return selectorobj
+}
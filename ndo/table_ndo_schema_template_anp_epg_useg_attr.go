@@ -0,0 +1,223 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateAnpEpgUsegAttr struct {
+	Id           string
+	SchemaId     string
+	TemplateName string
+	AnpName      string
+	EpgName      string
+	Name         string
+	DisplayName  string
+	Description  string
+	Type         string
+	Operator     string
+	Category     string
+	Value        string
+	UsegSubnet   bool
+	Raw          interface{}
+}
+
+func tableNDOSchemaTemplateAnpEpgUsegAttr() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_anp_epg_useg_attr",
+		Description: "NDO Schema-Template-Anp-Epg uSeg Attributes",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateAnpEpgUsegAttr,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "anp_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "epg_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateAnpEpgUsegAttr,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID of the Endpoint Group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template of the Endpoint Group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "anp_name",
+				Description: "(Required) Name of the Application Network Profile.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "epg_name",
+				Description: "(Required) Name of the Endpoint Group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "(Required) Name of the uSeg attribute.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "(Optional) The name as displayed on the MSO web interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "(Optional) The description of the uSeg attribute.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type",
+				Description: "(Required) The type of the uSeg attribute, e.g. ip, mac, dns, vm-name or tag.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "operator",
+				Description: "(Optional) The operator of the uSeg attribute. Allowed values are equals, contains, startsWith and endsWith.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "category",
+				Description: "(Optional) The category of a tag uSeg attribute.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "value",
+				Description: "(Optional) The value matched by the uSeg attribute.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "useg_subnet",
+				Description: "(Optional) Whether an ip uSeg attribute matches the subnets of the Endpoint Group instead of a value. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("UsegSubnet"),
+			},
+			{
+				Name:        "raw",
+				Description: "The full uSeg attribute object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateAnpEpgUsegAttr(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplateEpg(ctx, d, ndoclient, "epg_name", func(schemaId string, curtemp *container.Container, curanp *container.Container, curepg *container.Container) error {
+		attrlist, err := curepg.S("uSegAttrs").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, curattr := range attrlist {
+			attrobj := buildSchemaTemplateAnpEpgUsegAttr(schemaId, curtemp, curanp, curepg, curattr)
+			log.Printf("[TRACE] Record object: %v ", attrobj)
+			d.StreamListItem(ctx, attrobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateAnpEpgUsegAttr(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "anp", "epg", "uSegAttr")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curanp := findByName(curtemp.S("anps"), "name", idParts[2])
+	if curanp == nil {
+		return nil, nil
+	}
+
+	curepg := findByName(curanp.S("epgs"), "name", idParts[3])
+	if curepg == nil {
+		return nil, nil
+	}
+
+	curattr := findByName(curepg.S("uSegAttrs"), "name", idParts[4])
+	if curattr == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateAnpEpgUsegAttr(idParts[0], curtemp, curanp, curepg, curattr), nil
+}
+
+func buildSchemaTemplateAnpEpgUsegAttr(schemaId string, curtemp *container.Container, curanp *container.Container, curepg *container.Container, curattr *container.Container) *SchemaTemplateAnpEpgUsegAttr {
+	attrobj := &SchemaTemplateAnpEpgUsegAttr{}
+	attrobj.SchemaId = schemaId
+	attrobj.TemplateName = curtemp.Str("name")
+	attrobj.AnpName = curanp.Str("name")
+	attrobj.EpgName = curepg.Str("name")
+	attrobj.Name = curattr.Str("name")
+	attrobj.DisplayName = curattr.Str("displayName")
+	attrobj.Description = curattr.Str("description")
+	attrobj.Type = curattr.Str("type")
+	attrobj.Operator = curattr.Str("operator")
+	attrobj.Category = curattr.Str("category")
+	attrobj.Value = curattr.Str("value")
+	attrobj.UsegSubnet = curattr.Bool("fvSubnet")
+	attrobj.Raw = curattr.Data()
+	attrobj.Id = attrobj.SchemaId + "/template/" + attrobj.TemplateName + "/anp/" + attrobj.AnpName + "/epg/" + attrobj.EpgName + "/uSegAttr/" + attrobj.Name
+	return attrobj
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 
-	"steampipe-plugin-ndo/client"
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
 
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
@@ -14,30 +14,31 @@ import (
 )
 
 type SchemaTemplateBd struct {
-	Id                                string
-	SchemaId                          string
-	TemplateName                      string
-	Name                              string
-	DisplayName                       string
-	VrfName                           string
-	VrfSchemaId                       string
-	VrfTemplateName                   string
-	Layer2UnknownUnicast              string
-	IntersiteBumTraffic               string
-	OptimizeWanBandwidth              string
-	Layer2Stretch                     string
-	Layer3Multicast                   string
-	ArpFlooding                       string
-	VirtualMacAddress                 string
-	UnicastRouting                    string
-	Ipv6UnknownMulticastFlooding      string
-	MultiDestinationFlooding          string
-	UnknownMulticastFlooding          string
-	DhcpPolicy                        string
-	DhcpPolicyName                    string
-	DhcpPolicyVersion                 string
-	DhcpPolicyDhcpOptionPolicyName    string
-	DhcpPolicyDhcpOptionPolicyVersion string
+	Id                           string
+	SchemaId                     string
+	TemplateName                 string
+	Name                         string
+	DisplayName                  string
+	VrfName                      string
+	VrfSchemaId                  string
+	VrfTemplateName              string
+	Layer2UnknownUnicast         string
+	IntersiteBumTraffic          bool
+	OptimizeWanBandwidth         bool
+	Layer2Stretch                bool
+	Layer3Multicast              bool
+	ArpFlooding                  bool
+	VirtualMacAddress            string
+	UnicastRouting               bool
+	Ipv6UnknownMulticastFlooding string
+	MultiDestinationFlooding     string
+	UnknownMulticastFlooding     string
+	DhcpPolicy                   interface{}
+	DhcpPolicyName               string
+	DhcpPolicyVersion            int64
+	DhcpOptionPolicyName         string
+	DhcpOptionPolicyVersion      int64
+	Raw                          interface{}
 }
 
 func tableNDOSchemaTemplateBd() *plugin.Table {
@@ -48,11 +49,23 @@ func tableNDOSchemaTemplateBd() *plugin.Table {
 			Hydrate: listSchemaTemplateBd,
 			KeyColumns: []*plugin.KeyColumn{
 				{
-					Name:    "id",
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "name",
 					Require: plugin.Optional,
 				},
 			},
 		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateBd,
+		},
 		Columns: []*plugin.Column{
 			{
 				Name:        "id",
@@ -105,27 +118,32 @@ func tableNDOSchemaTemplateBd() *plugin.Table {
 			{
 				Name:        "intersite_bum_traffic",
 				Description: "(Optional) Boolean Flag to enable or disable intersite bum traffic. Default to false.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("IntersiteBumTraffic"),
 			},
 			{
 				Name:        "optimize_wan_bandwidth",
 				Description: "(Optional) Boolean flag to enable or disable the wan bandwidth optimization. Default to false.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("OptimizeWanBandwidth"),
 			},
 			{
 				Name:        "layer2_stretch",
 				Description: "(Optional) Boolean flag to enable or disable the layer-2 stretch. Default to false. Should enable this flag if you want to create subnets under this Bridge Domain.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Layer2Stretch"),
 			},
 			{
 				Name:        "layer3_multicast",
 				Description: "(Optional) Boolean flag to enable or disable layer 3 multicast traffic. Default to false.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Layer3Multicast"),
 			},
 			{
 				Name:        "arp_flooding",
 				Description: "(Optional) ARP Flooding status. Default to false.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ArpFlooding"),
 			},
 			{
 				Name:        "virtual_mac_address",
@@ -135,7 +153,8 @@ func tableNDOSchemaTemplateBd() *plugin.Table {
 			{
 				Name:        "unicast_routing",
 				Description: "(Optional) Unicast Routing status. Default to false.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("UnicastRouting"),
 			},
 			{
 				Name:        "ipv6_unknown_multicast_flooding",
@@ -154,32 +173,33 @@ func tableNDOSchemaTemplateBd() *plugin.Table {
 			},
 			{
 				Name:        "dhcp_policy",
-				Description: "(Optional) Map to provide dhcp_policy configurations.",
-				Type:        proto.ColumnType_STRING,
+				Description: "(Optional) The DHCP relay label of the Bridge Domain. The first one when it has several, see ndo_schema_template_bd_dhcp_label for all of them.",
+				Type:        proto.ColumnType_JSON,
 			},
 			{
-				Name:        "dhcp_policy.name",
-				Description: "(Optional) dhcp_policy name. Required if you specify the dhcp_policy.",
+				Name:        "dhcp_policy_name",
+				Description: "(Optional) Name of the DHCP relay policy.",
 				Type:        proto.ColumnType_STRING,
-				Transform:   transform.FromField("DhcpPolicyName"),
 			},
 			{
-				Name:        "dhcp_policy.version",
-				Description: "(Optional) Version of dhcp_policy. Required if you specify the dhcp_policy.",
-				Type:        proto.ColumnType_STRING,
-				Transform:   transform.FromField("DhcpPolicyVersion"),
+				Name:        "dhcp_policy_version",
+				Description: "(Optional) Version of the DHCP relay policy.",
+				Type:        proto.ColumnType_INT,
 			},
 			{
-				Name:        "dhcp_policy.dhcp_option_policy_name",
-				Description: "(Optional) Name of dhcp_option_policy.",
+				Name:        "dhcp_option_policy_name",
+				Description: "(Optional) Name of the DHCP option policy.",
 				Type:        proto.ColumnType_STRING,
-				Transform:   transform.FromField("DhcpPolicyDhcpOptionPolicyName"),
 			},
 			{
-				Name:        "dhcp_policy.dhcp_option_policy_version",
-				Description: "(Optional) Version of dhcp_option_policy. Required if you specify the dhcp_policy.dhcp_option_policy_name.",
-				Type:        proto.ColumnType_STRING,
-				Transform:   transform.FromField("DhcpPolicyDhcpOptionPolicyVersion"),
+				Name:        "dhcp_option_policy_version",
+				Description: "(Optional) Version of the DHCP option policy.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "raw",
+				Description: "The full Bridge Domain object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
 			},
 		},
 	}
@@ -192,72 +212,91 @@ func listSchemaTemplateBd(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		return nil, fmt.Errorf("Error connecting to ND: %v", err)
 	}
 
-	log.Printf("[DEBUG] Calling API: list-identity")
-	dnUrl := "/api/v1/schemas/list-identity"
-	identityList, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
+	err = forEachTemplateBd(ctx, d, ndoclient, "name", func(schemaId string, curtemp *container.Container, curbd *container.Container) error {
+		bdobj := buildSchemaTemplateBd(schemaId, curtemp, curbd)
+		log.Printf("[TRACE] Record object: %v ", bdobj)
+		d.StreamListItem(ctx, bdobj)
+		return nil
+	})
+
+	return nil, err
+}
 
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateBd(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "bd")
 	if err != nil {
-		return nil, fmt.Errorf("Error getting Identity List: %v\nURL: %v", err, dnUrl)
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
 	}
 
-	log.Printf("[TRACE] Indentity List: %v", identityList)
-	schemaobjlist, err := identityList.S("schemas").Children()
+	ndoclient, err := connect(ctx, d)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting Schema List: %v", err)
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
 	}
 
-	for _, curschema := range schemaobjlist {
-		dnUrl := "/api/v1/schemas/" + client.StripQuotes(curschema.S("id").String())
-		schemaDetails, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
 
-		templatelist, err := schemaDetails.S("templates").Children()
-		if err != nil {
-			return nil, fmt.Errorf("Error getting template list: %v", err)
-		}
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
 
-		for _, curtemp := range templatelist {
-			bdobjlist, err := curtemp.S("bds").Children()
-			if err != nil {
-				return nil, fmt.Errorf("Error getting Bd list: %v", err)
-			}
+	curbd := findByName(curtemp.S("bds"), "name", idParts[2])
+	if curbd == nil {
+		return nil, nil
+	}
 
-			for _, curbd := range bdobjlist {
-				bdobj := &SchemaTemplateBd{}
-				bdobj.SchemaId = client.StripQuotes(curschema.S("id").String())
-				bdobj.TemplateName = client.StripQuotes(curtemp.S("name").String())
-				bdobj.Name = client.StripQuotes(curbd.S("name").String())
-				bdobj.DisplayName = client.StripQuotes(curbd.S("displayName").String())
-				if client.StripQuotes(curbd.S("vrfRef").String()) != "" {
-					vrfinfo := strings.Split(client.StripQuotes(curbd.S("vrfRef").String()), "/")
-					bdobj.VrfName = vrfinfo[6]
-					bdobj.VrfSchemaId = vrfinfo[2]
-					bdobj.VrfTemplateName = vrfinfo[4]
-				}
-				bdobj.Layer2UnknownUnicast = client.StripQuotes(curbd.S("l2UnknownUnicast").String())
-				bdobj.IntersiteBumTraffic = client.StripQuotes(curbd.S("intersiteBumTrafficAllow").String())
-				bdobj.OptimizeWanBandwidth = client.StripQuotes(curbd.S("optimizeWanBandwidth").String())
-				bdobj.Layer2Stretch = client.StripQuotes(curbd.S("l2Stretch").String())
-				bdobj.Layer3Multicast = client.StripQuotes(curbd.S("l3MCast").String())
-				bdobj.ArpFlooding = client.StripQuotes(curbd.S("arpFlood").String())
-				//bdobj.VirtualMacAddress = client.StripQuotes(curbd.S("virtualmac").String())
-				bdobj.UnicastRouting = client.StripQuotes(curbd.S("unicastRouting").String())
-				bdobj.Ipv6UnknownMulticastFlooding = client.StripQuotes(curbd.S("v6unkMcastAct").String())
-				switch client.StripQuotes(curbd.S("multiDstPktAct").String()) {
-				case "bd-flood":
-					bdobj.MultiDestinationFlooding = "flood_in_bd"
-				case "drop":
-					bdobj.MultiDestinationFlooding = "drop"
-				case "encap-flood":
-					bdobj.MultiDestinationFlooding = "flood_in_encap"
-				}
+	return buildSchemaTemplateBd(idParts[0], curtemp, curbd), nil
+}
 
-				bdobj.UnknownMulticastFlooding = client.StripQuotes(curbd.S("unkMcastAct").String())
-				bdobj.Id = bdobj.SchemaId + "/template/" + bdobj.TemplateName + "/bd/" + bdobj.Name
-				log.Printf("[TRACE] Record object: %s ", bdobj)
-				d.StreamListItem(ctx, bdobj)
-			}
+func buildSchemaTemplateBd(schemaId string, curtemp *container.Container, curbd *container.Container) *SchemaTemplateBd {
+	bdobj := &SchemaTemplateBd{}
+	bdobj.SchemaId = schemaId
+	bdobj.TemplateName = curtemp.Str("name")
+	bdobj.Name = curbd.Str("name")
+	bdobj.DisplayName = curbd.Str("displayName")
+	if vrfRef := curbd.Str("vrfRef"); vrfRef != "" {
+		vrf, err := ref.ParseKind(vrfRef, "vrfs")
+		if err != nil {
+			log.Printf("[WARN] BD %s: %v", bdobj.Name, err)
+		} else {
+			bdobj.VrfName = vrf.Name()
+			bdobj.VrfSchemaId = vrf.SchemaId
+			bdobj.VrfTemplateName = vrf.TemplateName
 		}
 	}
+	bdobj.Layer2UnknownUnicast = curbd.Str("l2UnknownUnicast")
+	bdobj.IntersiteBumTraffic = curbd.Bool("intersiteBumTrafficAllow")
+	bdobj.OptimizeWanBandwidth = curbd.Bool("optimizeWanBandwidth")
+	bdobj.Layer2Stretch = curbd.Bool("l2Stretch")
+	bdobj.Layer3Multicast = curbd.Bool("l3MCast")
+	bdobj.ArpFlooding = curbd.Bool("arpFlood")
+	//bdobj.VirtualMacAddress = curbd.Str("virtualmac")
+	bdobj.UnicastRouting = curbd.Bool("unicastRouting")
+	bdobj.Ipv6UnknownMulticastFlooding = curbd.Str("v6unkMcastAct")
+	switch curbd.Str("multiDstPktAct") {
+	case "bd-flood":
+		bdobj.MultiDestinationFlooding = "flood_in_bd"
+	case "drop":
+		bdobj.MultiDestinationFlooding = "drop"
+	case "encap-flood":
+		bdobj.MultiDestinationFlooding = "flood_in_encap"
+	}
 
-	return nil, nil
+	bdobj.UnknownMulticastFlooding = curbd.Str("unkMcastAct")
+	if dhcpLabels := bdDhcpLabels(curbd); len(dhcpLabels) > 0 {
+		bdobj.DhcpPolicy = dhcpLabels[0].Data()
+		bdobj.DhcpPolicyName = dhcpLabels[0].Str("name")
+		bdobj.DhcpPolicyVersion = dhcpLabels[0].Int("version")
+		bdobj.DhcpOptionPolicyName = dhcpLabels[0].Str("dhcpOptionLabel", "name")
+		bdobj.DhcpOptionPolicyVersion = dhcpLabels[0].Int("dhcpOptionLabel", "version")
+	}
+	bdobj.Raw = curbd.Data()
+	bdobj.Id = bdobj.SchemaId + "/template/" + bdobj.TemplateName + "/bd/" + bdobj.Name
+	return bdobj
 }
@@ -0,0 +1,190 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateBdDhcpLabel struct {
+	Id                      string
+	SchemaId                string
+	TemplateName            string
+	BdName                  string
+	Name                    string
+	Version                 int64
+	DhcpOptionPolicyName    string
+	DhcpOptionPolicyVersion int64
+	Raw                     interface{}
+}
+
+func tableNDOSchemaTemplateBdDhcpLabel() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_bd_dhcp_label",
+		Description: "NDO Schema-Template-Bd DHCP Labels",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateBdDhcpLabel,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "bd_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateBdDhcpLabel,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID of the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template of the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "bd_name",
+				Description: "(Required) Name of the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "(Required) Name of the DHCP relay policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "version",
+				Description: "(Optional) Version of the DHCP relay policy.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "dhcp_option_policy_name",
+				Description: "(Optional) Name of the DHCP option policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dhcp_option_policy_version",
+				Description: "(Optional) Version of the DHCP option policy.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "raw",
+				Description: "The full DHCP label object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateBdDhcpLabel(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplateBd(ctx, d, ndoclient, "bd_name", func(schemaId string, curtemp *container.Container, curbd *container.Container) error {
+		for _, curlabel := range bdDhcpLabels(curbd) {
+			labelobj := buildSchemaTemplateBdDhcpLabel(schemaId, curtemp, curbd, curlabel)
+			log.Printf("[TRACE] Record object: %v ", labelobj)
+			d.StreamListItem(ctx, labelobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateBdDhcpLabel(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "bd", "dhcpLabel")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curbd := findByName(curtemp.S("bds"), "name", idParts[2])
+	if curbd == nil {
+		return nil, nil
+	}
+
+	for _, curlabel := range bdDhcpLabels(curbd) {
+		if curlabel.Str("name") == idParts[3] {
+			return buildSchemaTemplateBdDhcpLabel(idParts[0], curtemp, curbd, curlabel), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// bdDhcpLabels returns the DHCP labels of a BD. Older schemas carry a single
+// dhcpLabel object instead of the dhcpLabels list.
+func bdDhcpLabels(curbd *container.Container) []*container.Container {
+	if curbd.Exists("dhcpLabels") {
+		dhcpLabels, err := curbd.S("dhcpLabels").Children()
+		if err != nil {
+			return nil
+		}
+		return dhcpLabels
+	}
+	if curbd.Exists("dhcpLabel") && curbd.Str("dhcpLabel", "name") != "" {
+		return []*container.Container{curbd.S("dhcpLabel")}
+	}
+	return nil
+}
+
+func buildSchemaTemplateBdDhcpLabel(schemaId string, curtemp *container.Container, curbd *container.Container, curlabel *container.Container) *SchemaTemplateBdDhcpLabel {
+	labelobj := &SchemaTemplateBdDhcpLabel{}
+	labelobj.SchemaId = schemaId
+	labelobj.TemplateName = curtemp.Str("name")
+	labelobj.BdName = curbd.Str("name")
+	labelobj.Name = curlabel.Str("name")
+	labelobj.Version = curlabel.Int("version")
+	labelobj.DhcpOptionPolicyName = curlabel.Str("dhcpOptionLabel", "name")
+	labelobj.DhcpOptionPolicyVersion = curlabel.Int("dhcpOptionLabel", "version")
+	labelobj.Raw = curlabel.Data()
+	labelobj.Id = labelobj.SchemaId + "/template/" + labelobj.TemplateName + "/bd/" + labelobj.BdName + "/dhcpLabel/" + labelobj.Name
+	return labelobj
+}
@@ -0,0 +1,210 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateBdSubnet struct {
+	Id               string
+	SchemaId         string
+	TemplateName     string
+	BdName           string
+	GatewayIp        string
+	SubnetCidr       string
+	Scope            string
+	Shared           bool
+	NoDefaultGateway bool
+	Querier          bool
+	Primary          bool
+	Description      string
+	Raw              interface{}
+}
+
+func tableNDOSchemaTemplateBdSubnet() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_bd_subnet",
+		Description: "NDO Schema-Template-Bd Subnets",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateBdSubnet,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "bd_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateBdSubnet,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID of the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template of the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "bd_name",
+				Description: "(Required) Name of the Bridge Domain.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "gateway_ip",
+				Description: "(Required) The gateway IP and mask of the subnet as configured, e.g. 10.0.0.1/24.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "subnet_cidr",
+				Description: "(Required) The network of the subnet, e.g. 10.0.0.0/24 for gateway 10.0.0.1/24.",
+				Type:        proto.ColumnType_CIDR,
+			},
+			{
+				Name:        "scope",
+				Description: "(Optional) The scope of the subnet. Allowed values are private and public. Default to private.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "shared",
+				Description: "(Optional) Whether the subnet is shared between VRFs. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Shared"),
+			},
+			{
+				Name:        "no_default_gateway",
+				Description: "(Optional) Whether the subnet has no default SVI gateway. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("NoDefaultGateway"),
+			},
+			{
+				Name:        "querier",
+				Description: "(Optional) Whether the subnet is an IGMP querier. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Querier"),
+			},
+			{
+				Name:        "primary",
+				Description: "(Optional) Whether this is the primary IP address of the Bridge Domain. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Primary"),
+			},
+			{
+				Name:        "description",
+				Description: "(Optional) The description of the subnet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "raw",
+				Description: "The full subnet object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateBdSubnet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplateBd(ctx, d, ndoclient, "bd_name", func(schemaId string, curtemp *container.Container, curbd *container.Container) error {
+		subnetlist, err := curbd.S("subnets").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, cursubnet := range subnetlist {
+			subnetobj := buildSchemaTemplateBdSubnet(schemaId, curtemp, curbd, cursubnet)
+			log.Printf("[TRACE] Record object: %v ", subnetobj)
+			d.StreamListItem(ctx, subnetobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateBdSubnet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "bd", "subnet")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curbd := findByName(curtemp.S("bds"), "name", idParts[2])
+	if curbd == nil {
+		return nil, nil
+	}
+
+	cursubnet := findByName(curbd.S("subnets"), "ip", idParts[3])
+	if cursubnet == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateBdSubnet(idParts[0], curtemp, curbd, cursubnet), nil
+}
+
+func buildSchemaTemplateBdSubnet(schemaId string, curtemp *container.Container, curbd *container.Container, cursubnet *container.Container) *SchemaTemplateBdSubnet {
+	subnetobj := &SchemaTemplateBdSubnet{}
+	subnetobj.SchemaId = schemaId
+	subnetobj.TemplateName = curtemp.Str("name")
+	subnetobj.BdName = curbd.Str("name")
+	subnetobj.GatewayIp = cursubnet.Str("ip")
+	subnetobj.SubnetCidr = subnetCidr(subnetobj.GatewayIp)
+	subnetobj.Scope = cursubnet.Str("scope")
+	subnetobj.Shared = cursubnet.Bool("shared")
+	subnetobj.NoDefaultGateway = cursubnet.Bool("noDefaultGateway")
+	subnetobj.Querier = cursubnet.Bool("querier")
+	subnetobj.Primary = cursubnet.Bool("primary")
+	subnetobj.Description = cursubnet.Str("description")
+	subnetobj.Raw = cursubnet.Data()
+	subnetobj.Id = subnetobj.SchemaId + "/template/" + subnetobj.TemplateName + "/bd/" + subnetobj.BdName + "/subnet/" + subnetobj.GatewayIp
+	return subnetobj
+}
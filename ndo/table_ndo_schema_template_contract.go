@@ -0,0 +1,247 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateContract struct {
+	Id                  string
+	SchemaId            string
+	TemplateName        string
+	Name                string
+	DisplayName         string
+	Description         string
+	Scope               string
+	FilterType          string
+	Priority            string
+	Directives          []string
+	FilterRelationships []map[string]interface{}
+	Raw                 interface{}
+}
+
+// the filter relationship lists of a contract, by the direction they apply in.
+// A two-way contract uses filterRelationships, a one-way contract may use the
+// other two to filter each direction differently.
+var contractFilterRelationships = []struct {
+	key       string
+	direction string
+}{
+	{"filterRelationships", "both"},
+	{"filterRelationshipsProviderToConsumer", "provider_to_consumer"},
+	{"filterRelationshipsConsumerToProvider", "consumer_to_provider"},
+}
+
+func tableNDOSchemaTemplateContract() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_contract",
+		Description: "NDO Schema-Template-Contract",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateContract,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateContract,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID under which the contract is defined.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template where the contract is defined.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "(Required) Name of the contract.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "(Required) The name as displayed on the MSO web interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "(Optional) The description of the contract.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "scope",
+				Description: "(Optional) The scope of the contract. Allowed values are application-profile, context, global and tenant. Default to context.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "filter_type",
+				Description: "(Optional) The type of filters applied by the contract. Allowed values are bothWay and oneWay. Default to bothWay.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "priority",
+				Description: "(Optional) The QoS priority class of the contract.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "directives",
+				Description: "(Optional) The directives used by the filter relationships of the contract, e.g. [\"log\"].",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "filter_relationships",
+				Description: "(Optional) The filters of the contract, with the direction they apply in (both, provider_to_consumer or consumer_to_provider), the filter schema, template and name, action and directives.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "raw",
+				Description: "The full contract object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateContract(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplate(ctx, d, ndoclient, func(schemaId string, curtemp *container.Container) error {
+		contractlist, err := curtemp.S("contracts").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, curcontract := range contractlist {
+			if !matchesQual(d, "name", curcontract.Str("name")) {
+				continue
+			}
+
+			contractobj := buildSchemaTemplateContract(schemaId, curtemp, curcontract)
+			log.Printf("[TRACE] Record object: %v ", contractobj)
+			d.StreamListItem(ctx, contractobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateContract(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "contract")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curcontract := findByName(curtemp.S("contracts"), "name", idParts[2])
+	if curcontract == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateContract(idParts[0], curtemp, curcontract), nil
+}
+
+func buildSchemaTemplateContract(schemaId string, curtemp *container.Container, curcontract *container.Container) *SchemaTemplateContract {
+	contractobj := &SchemaTemplateContract{}
+	contractobj.SchemaId = schemaId
+	contractobj.TemplateName = curtemp.Str("name")
+	contractobj.Name = curcontract.Str("name")
+	contractobj.DisplayName = curcontract.Str("displayName")
+	contractobj.Description = curcontract.Str("description")
+	contractobj.Scope = curcontract.Str("scope")
+	contractobj.FilterType = curcontract.Str("filterType")
+	contractobj.Priority = curcontract.Str("prio")
+
+	seenDirectives := map[string]bool{}
+	for _, relationships := range contractFilterRelationships {
+		filterlist, err := curcontract.S(relationships.key).Children()
+		if err != nil {
+			continue
+		}
+
+		for _, curfilter := range filterlist {
+			directives := []string{}
+			if directivelist, err := curfilter.S("directives").Children(); err == nil {
+				for _, curdirective := range directivelist {
+					directive := curdirective.Str()
+					directives = append(directives, directive)
+					if !seenDirectives[directive] {
+						seenDirectives[directive] = true
+						contractobj.Directives = append(contractobj.Directives, directive)
+					}
+				}
+			}
+
+			relationship := map[string]interface{}{
+				"direction":  relationships.direction,
+				"action":     curfilter.Str("action"),
+				"directives": directives,
+			}
+			filter, err := ref.ParseKind(curfilter.Str("filterRef"), "filters")
+			if err != nil {
+				log.Printf("[WARN] Contract %s: %v", contractobj.Name, err)
+			} else {
+				relationship["filter_schema_id"] = filter.SchemaId
+				relationship["filter_template_name"] = filter.TemplateName
+				relationship["filter_name"] = filter.Name()
+			}
+			contractobj.FilterRelationships = append(contractobj.FilterRelationships, relationship)
+		}
+	}
+
+	contractobj.Raw = curcontract.Data()
+	contractobj.Id = contractobj.SchemaId + "/template/" + contractobj.TemplateName + "/contract/" + contractobj.Name
+	return contractobj
+}
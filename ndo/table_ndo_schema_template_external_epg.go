@@ -0,0 +1,225 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateExternalEpg struct {
+	Id                string
+	SchemaId          string
+	TemplateName      string
+	Name              string
+	DisplayName       string
+	Description       string
+	ExternalEpgType   string
+	VrfName           string
+	VrfSchemaId       string
+	VrfTemplateName   string
+	L3outName         string
+	L3outSchemaId     string
+	L3outTemplateName string
+	PreferredGroup    bool
+	Raw               interface{}
+}
+
+func tableNDOSchemaTemplateExternalEpg() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_external_epg",
+		Description: "NDO Schema-Template-External-Epg",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateExternalEpg,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateExternalEpg,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID under which the External EPG is defined.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template where the External EPG is defined.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "(Required) Name of the External EPG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "(Required) The name as displayed on the MSO web interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "(Optional) The description of the External EPG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "external_epg_type",
+				Description: "(Optional) The type of the External EPG. Allowed values are on-premise and cloud. Default to on-premise.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vrf_name",
+				Description: "(Required) Name of the VRF of the External EPG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vrf_schema_id",
+				Description: "(Optional) The schemaID that defines the referenced VRF.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VrfSchemaId"),
+			},
+			{
+				Name:        "vrf_template_name",
+				Description: "(Optional) The template that defines the referenced VRF.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "l3out_name",
+				Description: "(Optional) Name of the L3Out of the External EPG, when it is defined in a template.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "l3out_schema_id",
+				Description: "(Optional) The schemaID that defines the referenced L3Out.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("L3outSchemaId"),
+			},
+			{
+				Name:        "l3out_template_name",
+				Description: "(Optional) The template that defines the referenced L3Out.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "preferred_group",
+				Description: "(Optional) Whether the External EPG is a member of the preferred group. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("PreferredGroup"),
+			},
+			{
+				Name:        "raw",
+				Description: "The full External EPG object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateExternalEpg(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplateExternalEpg(ctx, d, ndoclient, "name", func(schemaId string, curtemp *container.Container, curextepg *container.Container) error {
+		extepgobj := buildSchemaTemplateExternalEpg(schemaId, curtemp, curextepg)
+		log.Printf("[TRACE] Record object: %v ", extepgobj)
+		d.StreamListItem(ctx, extepgobj)
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateExternalEpg(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "externalEpg")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curextepg := findByName(curtemp.S("externalEpgs"), "name", idParts[2])
+	if curextepg == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateExternalEpg(idParts[0], curtemp, curextepg), nil
+}
+
+func buildSchemaTemplateExternalEpg(schemaId string, curtemp *container.Container, curextepg *container.Container) *SchemaTemplateExternalEpg {
+	extepgobj := &SchemaTemplateExternalEpg{}
+	extepgobj.SchemaId = schemaId
+	extepgobj.TemplateName = curtemp.Str("name")
+	extepgobj.Name = curextepg.Str("name")
+	extepgobj.DisplayName = curextepg.Str("displayName")
+	extepgobj.Description = curextepg.Str("description")
+	extepgobj.ExternalEpgType = curextepg.Str("extEpgType")
+	if vrfRef := curextepg.Str("vrfRef"); vrfRef != "" {
+		vrf, err := ref.ParseKind(vrfRef, "vrfs")
+		if err != nil {
+			log.Printf("[WARN] External EPG %s: %v", extepgobj.Name, err)
+		} else {
+			extepgobj.VrfName = vrf.Name()
+			extepgobj.VrfSchemaId = vrf.SchemaId
+			extepgobj.VrfTemplateName = vrf.TemplateName
+		}
+	}
+	if l3outRef := curextepg.Str("l3outRef"); l3outRef != "" {
+		l3out, err := ref.ParseKind(l3outRef, "l3outs")
+		if err != nil {
+			log.Printf("[WARN] External EPG %s: %v", extepgobj.Name, err)
+		} else {
+			extepgobj.L3outName = l3out.Name()
+			extepgobj.L3outSchemaId = l3out.SchemaId
+			extepgobj.L3outTemplateName = l3out.TemplateName
+		}
+	}
+	extepgobj.PreferredGroup = curextepg.Bool("preferredGroup")
+	extepgobj.Raw = curextepg.Data()
+	extepgobj.Id = extepgobj.SchemaId + "/template/" + extepgobj.TemplateName + "/externalEpg/" + extepgobj.Name
+	return extepgobj
+}
@@ -0,0 +1,204 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateExternalEpgContract struct {
+	Id                   string
+	SchemaId             string
+	TemplateName         string
+	ExternalEpgName      string
+	RelationshipType     string
+	ContractSchemaId     string
+	ContractTemplateName string
+	ContractName         string
+	Raw                  interface{}
+}
+
+func tableNDOSchemaTemplateExternalEpgContract() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_external_epg_contract",
+		Description: "NDO Schema-Template-External-Epg Contract Relationships",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateExternalEpgContract,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "external_epg_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "relationship_type",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "contract_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateExternalEpgContract,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID of the External EPG.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template of the External EPG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "external_epg_name",
+				Description: "(Required) Name of the External EPG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "relationship_type",
+				Description: "(Required) Whether the External EPG provides or consumes the contract. Allowed values are provider and consumer.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "contract_schema_id",
+				Description: "(Required) The schemaID that defines the contract.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ContractSchemaId"),
+			},
+			{
+				Name:        "contract_template_name",
+				Description: "(Required) The template that defines the contract.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "contract_name",
+				Description: "(Required) Name of the contract.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "raw",
+				Description: "The full contract relationship object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateExternalEpgContract(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplateExternalEpg(ctx, d, ndoclient, "external_epg_name", func(schemaId string, curtemp *container.Container, curextepg *container.Container) error {
+		relationshiplist, err := curextepg.S("contractRelationships").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, currelationship := range relationshiplist {
+			contractobj := buildSchemaTemplateExternalEpgContract(schemaId, curtemp, curextepg, currelationship)
+			if !matchesQual(d, "relationship_type", contractobj.RelationshipType) || !matchesQual(d, "contract_name", contractobj.ContractName) {
+				continue
+			}
+
+			log.Printf("[TRACE] Record object: %v ", contractobj)
+			d.StreamListItem(ctx, contractobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateExternalEpgContract(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "externalEpg", "relationshipType", "contractSchema", "contractTemplate", "contract")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curextepg := findByName(curtemp.S("externalEpgs"), "name", idParts[2])
+	if curextepg == nil {
+		return nil, nil
+	}
+
+	relationshiplist, err := curextepg.S("contractRelationships").Children()
+	if err != nil {
+		return nil, nil
+	}
+
+	for _, currelationship := range relationshiplist {
+		contractobj := buildSchemaTemplateExternalEpgContract(idParts[0], curtemp, curextepg, currelationship)
+		if contractobj.Id == id {
+			return contractobj, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func buildSchemaTemplateExternalEpgContract(schemaId string, curtemp *container.Container, curextepg *container.Container, currelationship *container.Container) *SchemaTemplateExternalEpgContract {
+	contractobj := &SchemaTemplateExternalEpgContract{}
+	contractobj.SchemaId = schemaId
+	contractobj.TemplateName = curtemp.Str("name")
+	contractobj.ExternalEpgName = curextepg.Str("name")
+	contractobj.RelationshipType = currelationship.Str("relationshipType")
+	contract, err := ref.ParseKind(currelationship.Str("contractRef"), "contracts")
+	if err != nil {
+		log.Printf("[WARN] External EPG %s: %v", contractobj.ExternalEpgName, err)
+	} else {
+		contractobj.ContractSchemaId = contract.SchemaId
+		contractobj.ContractTemplateName = contract.TemplateName
+		contractobj.ContractName = contract.Name()
+	}
+	contractobj.Raw = currelationship.Data()
+	contractobj.Id = contractobj.SchemaId + "/template/" + contractobj.TemplateName + "/externalEpg/" + contractobj.ExternalEpgName + "/relationshipType/" + contractobj.RelationshipType + "/contractSchema/" + contractobj.ContractSchemaId + "/contractTemplate/" + contractobj.ContractTemplateName + "/contract/" + contractobj.ContractName
+	return contractobj
+}
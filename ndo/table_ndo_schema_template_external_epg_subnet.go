@@ -0,0 +1,249 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateExternalEpgSubnet struct {
+	Id                    string
+	SchemaId              string
+	TemplateName          string
+	ExternalEpgName       string
+	Ip                    string
+	SubnetCidr            string
+	Name                  string
+	Scope                 []string
+	Aggregate             []string
+	ExternalSubnet        bool
+	SharedSecurityImport  bool
+	ImportRouteControl    bool
+	ExportRouteControl    bool
+	SharedRouteControl    bool
+	AggregateImport       bool
+	AggregateExport       bool
+	AggregateSharedRoutes bool
+	Raw                   interface{}
+}
+
+func tableNDOSchemaTemplateExternalEpgSubnet() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_external_epg_subnet",
+		Description: "NDO Schema-Template-External-Epg Subnets",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateExternalEpgSubnet,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "external_epg_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateExternalEpgSubnet,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID of the External EPG.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template of the External EPG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "external_epg_name",
+				Description: "(Required) Name of the External EPG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ip",
+				Description: "(Required) The IP prefix classified by the subnet as configured, e.g. 0.0.0.0/0.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "subnet_cidr",
+				Description: "(Required) The network of the IP prefix, e.g. 10.1.1.0/24 for 10.1.1.5/24.",
+				Type:        proto.ColumnType_CIDR,
+			},
+			{
+				Name:        "name",
+				Description: "(Optional) Name of the subnet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "scope",
+				Description: "(Optional) The scope flags of the subnet, e.g. [\"import-security\", \"shared-rtctrl\"].",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "aggregate",
+				Description: "(Optional) The aggregate flags of the subnet, e.g. [\"shared-rtctrl\"].",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "external_subnet",
+				Description: "(Optional) Whether the subnet classifies traffic into the External EPG (import-security scope).",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ExternalSubnet"),
+			},
+			{
+				Name:        "shared_security_import",
+				Description: "(Optional) Whether the subnet classification is leaked to other VRFs (shared-security scope).",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("SharedSecurityImport"),
+			},
+			{
+				Name:        "import_route_control",
+				Description: "(Optional) Whether the subnet is used for import route control (import-rtctrl scope).",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ImportRouteControl"),
+			},
+			{
+				Name:        "export_route_control",
+				Description: "(Optional) Whether the subnet is used for export route control (export-rtctrl scope).",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ExportRouteControl"),
+			},
+			{
+				Name:        "shared_route_control",
+				Description: "(Optional) Whether the route is leaked to other VRFs (shared-rtctrl scope).",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("SharedRouteControl"),
+			},
+			{
+				Name:        "aggregate_import",
+				Description: "(Optional) Whether import route control applies to all prefixes within the subnet.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("AggregateImport"),
+			},
+			{
+				Name:        "aggregate_export",
+				Description: "(Optional) Whether export route control applies to all prefixes within the subnet.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("AggregateExport"),
+			},
+			{
+				Name:        "aggregate_shared_routes",
+				Description: "(Optional) Whether all prefixes within the subnet are leaked to other VRFs.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("AggregateSharedRoutes"),
+			},
+			{
+				Name:        "raw",
+				Description: "The full subnet object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateExternalEpgSubnet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplateExternalEpg(ctx, d, ndoclient, "external_epg_name", func(schemaId string, curtemp *container.Container, curextepg *container.Container) error {
+		subnetlist, err := curextepg.S("subnets").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, cursubnet := range subnetlist {
+			subnetobj := buildSchemaTemplateExternalEpgSubnet(schemaId, curtemp, curextepg, cursubnet)
+			log.Printf("[TRACE] Record object: %v ", subnetobj)
+			d.StreamListItem(ctx, subnetobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateExternalEpgSubnet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "externalEpg", "subnet")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curextepg := findByName(curtemp.S("externalEpgs"), "name", idParts[2])
+	if curextepg == nil {
+		return nil, nil
+	}
+
+	cursubnet := findByName(curextepg.S("subnets"), "ip", idParts[3])
+	if cursubnet == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateExternalEpgSubnet(idParts[0], curtemp, curextepg, cursubnet), nil
+}
+
+func buildSchemaTemplateExternalEpgSubnet(schemaId string, curtemp *container.Container, curextepg *container.Container, cursubnet *container.Container) *SchemaTemplateExternalEpgSubnet {
+	subnetobj := &SchemaTemplateExternalEpgSubnet{}
+	subnetobj.SchemaId = schemaId
+	subnetobj.TemplateName = curtemp.Str("name")
+	subnetobj.ExternalEpgName = curextepg.Str("name")
+	subnetobj.Ip = cursubnet.Str("ip")
+	subnetobj.SubnetCidr = subnetCidr(subnetobj.Ip)
+	subnetobj.Name = cursubnet.Str("name")
+	subnetobj.Scope = cursubnet.Strs("scope")
+	subnetobj.Aggregate = cursubnet.Strs("aggregate")
+	subnetobj.ExternalSubnet = containsString(subnetobj.Scope, "import-security")
+	subnetobj.SharedSecurityImport = containsString(subnetobj.Scope, "shared-security")
+	subnetobj.ImportRouteControl = containsString(subnetobj.Scope, "import-rtctrl")
+	subnetobj.ExportRouteControl = containsString(subnetobj.Scope, "export-rtctrl")
+	subnetobj.SharedRouteControl = containsString(subnetobj.Scope, "shared-rtctrl")
+	subnetobj.AggregateImport = containsString(subnetobj.Aggregate, "import-rtctrl")
+	subnetobj.AggregateExport = containsString(subnetobj.Aggregate, "export-rtctrl")
+	subnetobj.AggregateSharedRoutes = containsString(subnetobj.Aggregate, "shared-rtctrl")
+	subnetobj.Raw = cursubnet.Data()
+	subnetobj.Id = subnetobj.SchemaId + "/template/" + subnetobj.TemplateName + "/externalEpg/" + subnetobj.ExternalEpgName + "/subnet/" + subnetobj.Ip
+	return subnetobj
+}
@@ -0,0 +1,151 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateFilter struct {
+	Id           string
+	SchemaId     string
+	TemplateName string
+	Name         string
+	DisplayName  string
+	Description  string
+	Raw          interface{}
+}
+
+func tableNDOSchemaTemplateFilter() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_filter",
+		Description: "NDO Schema-Template-Filter",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateFilter,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateFilter,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID under which the filter is defined.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template where the filter is defined.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "(Required) Name of the filter.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "(Required) The name as displayed on the MSO web interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "(Optional) The description of the filter.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "raw",
+				Description: "The full filter object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateFilter(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplateFilter(ctx, d, ndoclient, "name", func(schemaId string, curtemp *container.Container, curfilter *container.Container) error {
+		filterobj := buildSchemaTemplateFilter(schemaId, curtemp, curfilter)
+		log.Printf("[TRACE] Record object: %v ", filterobj)
+		d.StreamListItem(ctx, filterobj)
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateFilter(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "filter")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curfilter := findByName(curtemp.S("filters"), "name", idParts[2])
+	if curfilter == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateFilter(idParts[0], curtemp, curfilter), nil
+}
+
+func buildSchemaTemplateFilter(schemaId string, curtemp *container.Container, curfilter *container.Container) *SchemaTemplateFilter {
+	filterobj := &SchemaTemplateFilter{}
+	filterobj.SchemaId = schemaId
+	filterobj.TemplateName = curtemp.Str("name")
+	filterobj.Name = curfilter.Str("name")
+	filterobj.DisplayName = curfilter.Str("displayName")
+	filterobj.Description = curfilter.Str("description")
+	filterobj.Raw = curfilter.Data()
+	filterobj.Id = filterobj.SchemaId + "/template/" + filterobj.TemplateName + "/filter/" + filterobj.Name
+	return filterobj
+}
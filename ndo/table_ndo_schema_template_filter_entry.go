@@ -0,0 +1,253 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateFilterEntry struct {
+	Id                 string
+	SchemaId           string
+	TemplateName       string
+	FilterName         string
+	Name               string
+	DisplayName        string
+	Description        string
+	EtherType          string
+	ArpFlag            string
+	IpProtocol         string
+	MatchOnlyFragments bool
+	Stateful           bool
+	SourceFrom         string
+	SourceTo           string
+	DestinationFrom    string
+	DestinationTo      string
+	TcpSessionRules    interface{}
+	Raw                interface{}
+}
+
+func tableNDOSchemaTemplateFilterEntry() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_filter_entry",
+		Description: "NDO Schema-Template-Filter Entries",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateFilterEntry,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "filter_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateFilterEntry,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID under which the filter is defined.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template where the filter is defined.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "filter_name",
+				Description: "(Required) Name of the filter.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "(Required) Name of the filter entry.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "(Required) The name as displayed on the MSO web interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "(Optional) The description of the filter entry.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ether_type",
+				Description: "(Optional) The ether type matched by the entry, e.g. ip, arp or unspecified.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arp_flag",
+				Description: "(Optional) The ARP flag matched by the entry. Allowed values are request, reply and unspecified.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ip_protocol",
+				Description: "(Optional) The IP protocol matched by the entry, e.g. tcp, udp or unspecified.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "match_only_fragments",
+				Description: "(Optional) Whether the entry only matches fragments. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("MatchOnlyFragments"),
+			},
+			{
+				Name:        "stateful",
+				Description: "(Optional) Whether the entry is stateful. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Stateful"),
+			},
+			{
+				Name:        "source_from",
+				Description: "(Optional) Start of the source port range, e.g. 1024 or unspecified.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_to",
+				Description: "(Optional) End of the source port range.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination_from",
+				Description: "(Optional) Start of the destination port range, e.g. https or 8443.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination_to",
+				Description: "(Optional) End of the destination port range.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "tcp_session_rules",
+				Description: "(Optional) The TCP flags matched by the entry, e.g. [\"established\"].",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "raw",
+				Description: "The full filter entry object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateFilterEntry(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplateFilter(ctx, d, ndoclient, "filter_name", func(schemaId string, curtemp *container.Container, curfilter *container.Container) error {
+		entrylist, err := curfilter.S("entries").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, curentry := range entrylist {
+			if !matchesQual(d, "name", curentry.Str("name")) {
+				continue
+			}
+
+			entryobj := buildSchemaTemplateFilterEntry(schemaId, curtemp, curfilter, curentry)
+			log.Printf("[TRACE] Record object: %v ", entryobj)
+			d.StreamListItem(ctx, entryobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateFilterEntry(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "filter", "entry")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curfilter := findByName(curtemp.S("filters"), "name", idParts[2])
+	if curfilter == nil {
+		return nil, nil
+	}
+
+	curentry := findByName(curfilter.S("entries"), "name", idParts[3])
+	if curentry == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateFilterEntry(idParts[0], curtemp, curfilter, curentry), nil
+}
+
+func buildSchemaTemplateFilterEntry(schemaId string, curtemp *container.Container, curfilter *container.Container, curentry *container.Container) *SchemaTemplateFilterEntry {
+	entryobj := &SchemaTemplateFilterEntry{}
+	entryobj.SchemaId = schemaId
+	entryobj.TemplateName = curtemp.Str("name")
+	entryobj.FilterName = curfilter.Str("name")
+	entryobj.Name = curentry.Str("name")
+	entryobj.DisplayName = curentry.Str("displayName")
+	entryobj.Description = curentry.Str("description")
+	entryobj.EtherType = curentry.Str("etherType")
+	entryobj.ArpFlag = curentry.Str("arpFlag")
+	entryobj.IpProtocol = curentry.Str("ipProtocol")
+	entryobj.MatchOnlyFragments = curentry.Bool("matchOnlyFragments")
+	entryobj.Stateful = curentry.Bool("stateful")
+	entryobj.SourceFrom = curentry.Str("sourceFrom")
+	entryobj.SourceTo = curentry.Str("sourceTo")
+	entryobj.DestinationFrom = curentry.Str("destinationFrom")
+	entryobj.DestinationTo = curentry.Str("destinationTo")
+	if curentry.Exists("tcpSessionRules") {
+		entryobj.TcpSessionRules = curentry.S("tcpSessionRules").Data()
+	}
+	entryobj.Raw = curentry.Data()
+	entryobj.Id = entryobj.SchemaId + "/template/" + entryobj.TemplateName + "/filter/" + entryobj.FilterName + "/entry/" + entryobj.Name
+	return entryobj
+}
@@ -0,0 +1,187 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateL3out struct {
+	Id              string
+	SchemaId        string
+	TemplateName    string
+	Name            string
+	DisplayName     string
+	VrfName         string
+	VrfSchemaId     string
+	VrfTemplateName string
+	Raw             interface{}
+}
+
+func tableNDOSchemaTemplateL3out() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_l3out",
+		Description: "NDO Schema-Template-L3out",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateL3out,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateL3out,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID under which the L3Out is defined.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template where the L3Out is defined.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "(Required) Name of the L3Out.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "(Required) The name as displayed on the MSO web interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vrf_name",
+				Description: "(Required) Name of the VRF of the L3Out.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vrf_schema_id",
+				Description: "(Optional) The schemaID that defines the referenced VRF.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VrfSchemaId"),
+			},
+			{
+				Name:        "vrf_template_name",
+				Description: "(Optional) The template that defines the referenced VRF.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "raw",
+				Description: "The full L3Out object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateL3out(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplate(ctx, d, ndoclient, func(schemaId string, curtemp *container.Container) error {
+		// template L3Outs are stored as intersite L3Outs in the schema document
+		l3outlist, err := curtemp.S("intersiteL3outs").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, curl3out := range l3outlist {
+			if !matchesQual(d, "name", curl3out.Str("name")) {
+				continue
+			}
+
+			l3outobj := buildSchemaTemplateL3out(schemaId, curtemp, curl3out)
+			log.Printf("[TRACE] Record object: %v ", l3outobj)
+			d.StreamListItem(ctx, l3outobj)
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateL3out(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "l3out")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curl3out := findByName(curtemp.S("intersiteL3outs"), "name", idParts[2])
+	if curl3out == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateL3out(idParts[0], curtemp, curl3out), nil
+}
+
+func buildSchemaTemplateL3out(schemaId string, curtemp *container.Container, curl3out *container.Container) *SchemaTemplateL3out {
+	l3outobj := &SchemaTemplateL3out{}
+	l3outobj.SchemaId = schemaId
+	l3outobj.TemplateName = curtemp.Str("name")
+	l3outobj.Name = curl3out.Str("name")
+	l3outobj.DisplayName = curl3out.Str("displayName")
+	if vrfRef := curl3out.Str("vrfRef"); vrfRef != "" {
+		vrf, err := ref.ParseKind(vrfRef, "vrfs")
+		if err != nil {
+			log.Printf("[WARN] L3Out %s: %v", l3outobj.Name, err)
+		} else {
+			l3outobj.VrfName = vrf.Name()
+			l3outobj.VrfSchemaId = vrf.SchemaId
+			l3outobj.VrfTemplateName = vrf.TemplateName
+		}
+	}
+	l3outobj.Raw = curl3out.Data()
+	l3outobj.Id = l3outobj.SchemaId + "/template/" + l3outobj.TemplateName + "/l3out/" + l3outobj.Name
+	return l3outobj
+}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"log"
 
-	"steampipe-plugin-ndo/client"
+	"steampipe-plugin-ndo/container"
 
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
@@ -13,13 +13,17 @@ import (
 )
 
 type SchemaTemplateVrf struct {
-	Id              string
-	SchemaId        string
-	Name            string
-	Template        string
-	DisplayName     string
-	Layer3Multicast string
-	Vzany           string
+	Id                  string
+	SchemaId            string
+	Name                string
+	Template            string
+	DisplayName         string
+	Layer3Multicast     bool
+	Vzany               bool
+	PreferredGroup      bool
+	IpDataPlaneLearning string
+	RendezvousPoints    interface{}
+	Raw                 interface{}
 }
 
 func tableNDOSchemaTemplateVrf() *plugin.Table {
@@ -30,11 +34,23 @@ func tableNDOSchemaTemplateVrf() *plugin.Table {
 			Hydrate: listSchemaTemplateVrf,
 			KeyColumns: []*plugin.KeyColumn{
 				{
-					Name:    "id",
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "name",
 					Require: plugin.Optional,
 				},
 			},
 		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateVrf,
+		},
 		Columns: []*plugin.Column{
 			{
 				Name:        "id",
@@ -66,13 +82,36 @@ func tableNDOSchemaTemplateVrf() *plugin.Table {
 			{
 				Name:        "layer3_multicast",
 				Description: "(Optional) Whether to enable L3 multicast.",
-				Type:        proto.ColumnType_STRING,
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Layer3Multicast"),
 			},
 			{
 				Name:        "vzany",
 				Description: "(Optional) Whether to enable vzany.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Vzany"),
+			},
+			{
+				Name:        "preferred_group",
+				Description: "(Optional) Whether the preferred group is enabled for the VRF. Default to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("PreferredGroup"),
+			},
+			{
+				Name:        "ip_data_plane_learning",
+				Description: "(Optional) Whether IP data plane learning is enabled for the VRF. Allowed values are enabled and disabled. Default to enabled.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "rendezvous_points",
+				Description: "(Optional) The multicast rendezvous points of the VRF, with their IP address and type.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "raw",
+				Description: "The full VRF object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
 		},
 	}
 }
@@ -84,49 +123,85 @@ func listSchemaTemplateVrf(ctx context.Context, d *plugin.QueryData, h *plugin.H
 		return nil, fmt.Errorf("Error connecting to ND: %v", err)
 	}
 
-	log.Printf("[DEBUG] Calling API: list-identity")
-	dnUrl := "/api/v1/schemas/list-identity"
-	identityList, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
-
-	if err != nil {
-		return nil, fmt.Errorf("Error getting Identity List: %v\nURL: %v", err, dnUrl)
-	}
-
-	log.Printf("[TRACE] Indentity List: %v", identityList)
-	schemaobjlist, err := identityList.S("schemas").Children()
-	if err != nil {
-		return nil, fmt.Errorf("Error getting Schema List: %v", err)
-	}
-
-	for _, curschema := range schemaobjlist {
-		dnUrl := "/api/v1/schemas/" + client.StripQuotes(curschema.S("id").String())
-		schemaDetails, err := ndoclient.ServiceManager.GetViaURL(dnUrl)
-
+	err = forEachSchema(ctx, d, ndoclient, func(schemaId string, schemaDetails *container.Container) error {
 		templatelist, err := schemaDetails.S("templates").Children()
 		if err != nil {
-			return nil, fmt.Errorf("Error getting template list: %v", err)
+			return fmt.Errorf("Error getting template list: %v", err)
 		}
 
 		for _, curtemp := range templatelist {
+			if !matchesQual(d, "template", curtemp.Str("name")) {
+				continue
+			}
+
 			vrfobjlist, err := curtemp.S("vrfs").Children()
 			if err != nil {
-				return nil, fmt.Errorf("Error getting vrf list: %v", err)
+				return fmt.Errorf("Error getting vrf list: %v", err)
 			}
 
 			for _, curvrf := range vrfobjlist {
-				vrfobj := &SchemaTemplateVrf{}
-				vrfobj.SchemaId = client.StripQuotes(curschema.S("id").String())
-				vrfobj.Name = client.StripQuotes(curvrf.S("name").String())
-				vrfobj.Template = client.StripQuotes(curtemp.S("name").String())
-				vrfobj.DisplayName = client.StripQuotes(curvrf.S("displayName").String())
-				vrfobj.Layer3Multicast = client.StripQuotes(curvrf.S("l3MCast").String())
-				vrfobj.Vzany = client.StripQuotes(curvrf.S("vzAnyEnabled").String())
-				vrfobj.Id = vrfobj.SchemaId + "/template/" + vrfobj.Template + "/vrf/" + vrfobj.Name
-				log.Printf("[TRACE] Record object: %s ", vrfobj)
+				if !matchesQual(d, "name", curvrf.Str("name")) {
+					continue
+				}
+
+				vrfobj := buildSchemaTemplateVrf(schemaId, curtemp, curvrf)
+				log.Printf("[TRACE] Record object: %v ", vrfobj)
 				d.StreamListItem(ctx, vrfobj)
 			}
 		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateVrf(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "vrf")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
 	}
 
-	return nil, nil
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curvrf := findByName(curtemp.S("vrfs"), "name", idParts[2])
+	if curvrf == nil {
+		return nil, nil
+	}
+
+	return buildSchemaTemplateVrf(idParts[0], curtemp, curvrf), nil
+}
+
+func buildSchemaTemplateVrf(schemaId string, curtemp *container.Container, curvrf *container.Container) *SchemaTemplateVrf {
+	vrfobj := &SchemaTemplateVrf{}
+	vrfobj.SchemaId = schemaId
+	vrfobj.Name = curvrf.Str("name")
+	vrfobj.Template = curtemp.Str("name")
+	vrfobj.DisplayName = curvrf.Str("displayName")
+	vrfobj.Layer3Multicast = curvrf.Bool("l3MCast")
+	vrfobj.Vzany = curvrf.Bool("vzAnyEnabled")
+	vrfobj.PreferredGroup = curvrf.Bool("preferredGroup")
+	vrfobj.IpDataPlaneLearning = curvrf.Str("ipDataPlaneLearning")
+	if curvrf.Exists("rpConfigs") {
+		vrfobj.RendezvousPoints = curvrf.S("rpConfigs").Data()
+	}
+	vrfobj.Raw = curvrf.Data()
+	vrfobj.Id = vrfobj.SchemaId + "/template/" + vrfobj.Template + "/vrf/" + vrfobj.Name
+	return vrfobj
 }
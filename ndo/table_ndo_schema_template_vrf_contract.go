@@ -0,0 +1,225 @@
+package ndo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"steampipe-plugin-ndo/container"
+	"steampipe-plugin-ndo/ref"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+type SchemaTemplateVrfContract struct {
+	Id                   string
+	SchemaId             string
+	TemplateName         string
+	VrfName              string
+	RelationshipType     string
+	ContractSchemaId     string
+	ContractTemplateName string
+	ContractName         string
+	Raw                  interface{}
+}
+
+// the vzAny contract lists of a VRF, by relationship type
+var vrfVzAnyContracts = []struct {
+	key              string
+	relationshipType string
+}{
+	{"vzAnyProviderContracts", "provider"},
+	{"vzAnyConsumerContracts", "consumer"},
+}
+
+func tableNDOSchemaTemplateVrfContract() *plugin.Table {
+	return &plugin.Table{
+		Name:        "ndo_schema_template_vrf_contract",
+		Description: "NDO Schema-Template-Vrf vzAny Contracts",
+		List: &plugin.ListConfig{
+			Hydrate: listSchemaTemplateVrfContract,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "schema_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "template_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "vrf_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "relationship_type",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "contract_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSchemaTemplateVrfContract,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "(Required) Unique ID of this object within Nexus Dashboard Orchestrator (NDO)",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "schema_id",
+				Description: "(Required) SchemaID of the VRF.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SchemaId"),
+			},
+			{
+				Name:        "template_name",
+				Description: "(Required) Template of the VRF.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vrf_name",
+				Description: "(Required) Name of the VRF.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "relationship_type",
+				Description: "(Required) Whether vzAny of the VRF provides or consumes the contract. Allowed values are provider and consumer.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "contract_schema_id",
+				Description: "(Required) The schemaID that defines the contract.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ContractSchemaId"),
+			},
+			{
+				Name:        "contract_template_name",
+				Description: "(Required) The template that defines the contract.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "contract_name",
+				Description: "(Required) Name of the contract.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "raw",
+				Description: "The full vzAny contract object from the schema document, for attributes that have no column of their own.",
+				Type:        proto.ColumnType_JSON,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+func listSchemaTemplateVrfContract(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	err = forEachTemplate(ctx, d, ndoclient, func(schemaId string, curtemp *container.Container) error {
+		vrfobjlist, err := curtemp.S("vrfs").Children()
+		if err != nil {
+			return nil
+		}
+
+		for _, curvrf := range vrfobjlist {
+			if !matchesQual(d, "vrf_name", curvrf.Str("name")) {
+				continue
+			}
+
+			for _, contractobj := range buildSchemaTemplateVrfContracts(schemaId, curtemp, curvrf) {
+				if !matchesQual(d, "relationship_type", contractobj.RelationshipType) || !matchesQual(d, "contract_name", contractobj.ContractName) {
+					continue
+				}
+
+				log.Printf("[TRACE] Record object: %v ", contractobj)
+				d.StreamListItem(ctx, contractobj)
+			}
+		}
+
+		return nil
+	})
+
+	return nil, err
+}
+
+//// HYDRATE FUNCTIONS
+func getSchemaTemplateVrfContract(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.KeyColumnQuals["id"].GetStringValue()
+	idParts, err := parseCompositeId(id, "template", "vrf", "relationshipType", "contractSchema", "contractTemplate", "contract")
+	if err != nil {
+		log.Printf("[DEBUG] %v", err)
+		return nil, nil
+	}
+
+	ndoclient, err := connect(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to ND: %v", err)
+	}
+
+	schemaDetails, err := getSchemaDetails(ctx, d, ndoclient, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	curtemp := findByName(schemaDetails.S("templates"), "name", idParts[1])
+	if curtemp == nil {
+		return nil, nil
+	}
+
+	curvrf := findByName(curtemp.S("vrfs"), "name", idParts[2])
+	if curvrf == nil {
+		return nil, nil
+	}
+
+	for _, contractobj := range buildSchemaTemplateVrfContracts(idParts[0], curtemp, curvrf) {
+		if contractobj.Id == id {
+			return contractobj, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// buildSchemaTemplateVrfContracts builds a row for every contract provided or
+// consumed by vzAny of the VRF
+func buildSchemaTemplateVrfContracts(schemaId string, curtemp *container.Container, curvrf *container.Container) []*SchemaTemplateVrfContract {
+	contractobjs := []*SchemaTemplateVrfContract{}
+	for _, vzAnyContracts := range vrfVzAnyContracts {
+		contractlist, err := curvrf.S(vzAnyContracts.key).Children()
+		if err != nil {
+			continue
+		}
+
+		for _, curcontract := range contractlist {
+			contractobj := &SchemaTemplateVrfContract{}
+			contractobj.SchemaId = schemaId
+			contractobj.TemplateName = curtemp.Str("name")
+			contractobj.VrfName = curvrf.Str("name")
+			contractobj.RelationshipType = vzAnyContracts.relationshipType
+			contract, err := ref.ParseKind(curcontract.Str("contractRef"), "contracts")
+			if err != nil {
+				log.Printf("[WARN] VRF %s: %v", contractobj.VrfName, err)
+			} else {
+				contractobj.ContractSchemaId = contract.SchemaId
+				contractobj.ContractTemplateName = contract.TemplateName
+				contractobj.ContractName = contract.Name()
+			}
+			contractobj.Raw = curcontract.Data()
+			contractobj.Id = contractobj.SchemaId + "/template/" + contractobj.TemplateName + "/vrf/" + contractobj.VrfName + "/relationshipType/" + contractobj.RelationshipType + "/contractSchema/" + contractobj.ContractSchemaId + "/contractTemplate/" + contractobj.ContractTemplateName + "/contract/" + contractobj.ContractName
+			contractobjs = append(contractobjs, contractobj)
+		}
+	}
+	return contractobjs
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
+	"strconv"
+	"strings"
 
 	"steampipe-plugin-ndo/client"
+	"steampipe-plugin-ndo/container"
 
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
 )
@@ -26,6 +30,9 @@ func connect(ctx context.Context, d *plugin.QueryData) (*client.Client, error) {
 	password := ""
 	loginDomain := "DefaultAuth"
 	platform := "nd"
+	maxRetries := client.DefaultMaxRetries
+	backoffMinDelay := client.DefaultBackoffMinDelay
+	backoffMaxDelay := client.DefaultBackoffMaxDelay
 
 	// Override potential env values with config values
 	if ndoConfig.AllowUnverifiedSSL != nil {
@@ -51,6 +58,19 @@ func connect(ctx context.Context, d *plugin.QueryData) (*client.Client, error) {
 	if ndoConfig.Platform != nil {
 		platform = *ndoConfig.Platform
 	}
+
+	if ndoConfig.MaxRetries != nil {
+		maxRetries = *ndoConfig.MaxRetries
+	}
+
+	if ndoConfig.BackoffMinDelay != nil {
+		backoffMinDelay = *ndoConfig.BackoffMinDelay
+	}
+
+	if ndoConfig.BackoffMaxDelay != nil {
+		backoffMaxDelay = *ndoConfig.BackoffMaxDelay
+	}
+
 	// Make sure we have all required arguments set via either env or config
 	if clusterURI == "" || user == "" || password == "" || platform == "" {
 		errorMsg := ""
@@ -66,17 +86,161 @@ func connect(ctx context.Context, d *plugin.QueryData) (*client.Client, error) {
 		return nil, fmt.Errorf("Error in configuraiton: %s", errorMsg)
 	}
 
-	log.Printf("[TRACE] Connection config:\n[TRACE] URI: %s\n[TRACE] User: %s\n[TRACE] Domain: %s\n[TRACE] Platform: %s", clusterURI, user, loginDomain, platform)
+	if maxRetries < 0 || backoffMinDelay < 0 || backoffMaxDelay < backoffMinDelay {
+		return nil, fmt.Errorf("Error in configuraiton: max_retries and backoff delays must not be negative and backoff_max_delay must not be less than backoff_min_delay")
+	}
+
+	log.Printf("[TRACE] Connection config:\n[TRACE] URI: %s\n[TRACE] User: %s\n[TRACE] Domain: %s\n[TRACE] Platform: %s\n[TRACE] Max Retries: %d\n[TRACE] Backoff: %d-%ds", clusterURI, user, loginDomain, platform, maxRetries, backoffMinDelay, backoffMaxDelay)
+
+	options := []client.Option{
+		client.Password(password),
+		client.Insecure(allowUnverifiedSSL),
+		client.Domain(loginDomain),
+		client.MaxRetries(maxRetries),
+		client.BackoffMinDelay(backoffMinDelay),
+		client.BackoffMaxDelay(backoffMaxDelay),
+	}
 
 	if platform == "nd" {
-		ndoClient := client.GetClient(clusterURI, user, client.Password(password), client.Insecure(allowUnverifiedSSL), client.Domain(loginDomain), client.Platform("nd"))
+		ndoClient := client.GetClient(clusterURI, user, append(options, client.Platform("nd"))...)
 		log.Printf("[DEBUG] Got ND client")
 		log.Printf("[TRACE] client: %v", ndoClient)
 		return ndoClient, nil
 	} else {
-		ndoClient := client.GetClient(clusterURI, user, client.Password(password), client.Insecure(allowUnverifiedSSL), client.Domain(loginDomain))
+		ndoClient := client.GetClient(clusterURI, user, options...)
 		log.Printf("[DEBUG] Got MSO client")
 		log.Printf("[TRACE] client: %v", ndoClient)
 		return ndoClient, nil
 	}
 }
+
+// qualString returns the value of the equals qual on column, or "" if the query has none
+func qualString(d *plugin.QueryData, column string) string {
+	if qual, ok := d.KeyColumnQuals[column]; ok {
+		return qual.GetStringValue()
+	}
+	return ""
+}
+
+// matchesQual reports whether value satisfies the equals qual on column, if there is one
+func matchesQual(d *plugin.QueryData, column string, value string) bool {
+	qual := qualString(d, column)
+	return qual == "" || qual == value
+}
+
+// parseCompositeId splits an id built as <schemaId>/<key>/<value>/<key>/<value>...
+// into the schema id followed by the values, checking each key. Values may be
+// empty. The last value takes the rest of the id, so it may contain slashes.
+func parseCompositeId(id string, keys ...string) ([]string, error) {
+	parts := strings.SplitN(id, "/", 2*len(keys)+1)
+	if len(parts) != 2*len(keys)+1 || parts[0] == "" {
+		return nil, fmt.Errorf("Invalid id %q: expected <schema_id>/%s/...", id, strings.Join(keys, "/.../"))
+	}
+
+	values := []string{parts[0]}
+	for i, key := range keys {
+		if parts[2*i+1] != key {
+			return nil, fmt.Errorf("Invalid id %q: expected %q segment", id, key)
+		}
+		values = append(values, parts[2*i+2])
+	}
+	return values, nil
+}
+
+// parseSelectorId splits the selector part of an id, either <name> for a selector
+// without expressions or <name>/expression/<index>, returning -1 as index for the former
+func parseSelectorId(value string) (string, int, error) {
+	parts := strings.SplitN(value, "/expression/", 2)
+	if len(parts) == 1 {
+		return parts[0], -1, nil
+	}
+
+	index, err := strconv.Atoi(parts[1])
+	if err != nil || index < 0 {
+		return "", 0, fmt.Errorf("Invalid selector %q: expected <name>/expression/<index>", value)
+	}
+	return parts[0], index, nil
+}
+
+// selectorSuffix returns the selector part of an id, see parseSelectorId
+func selectorSuffix(name string, index *int) string {
+	if index == nil {
+		return name
+	}
+	return name + "/expression/" + strconv.Itoa(*index)
+}
+
+// subnetCidr returns the network of a gateway address such as 10.0.0.1/24, i.e.
+// 10.0.0.0/24, or "" if ip is not in CIDR notation. A cidr column rejects
+// addresses with host bits set, so the gateway itself goes in a string column.
+func subnetCidr(ip string) string {
+	_, ipnet, err := net.ParseCIDR(ip)
+	if err != nil {
+		log.Printf("[WARN] Subnet %q is not in CIDR notation: %v", ip, err)
+		return ""
+	}
+	return ipnet.String()
+}
+
+// findByName returns the object in list whose nameKey attribute equals name, or nil
+func findByName(list *container.Container, nameKey string, name string) *container.Container {
+	children, err := list.Children()
+	if err != nil {
+		return nil
+	}
+	for _, child := range children {
+		if child.Str(nameKey) == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// selectorExpressionIndexes returns the index of each expression of a selector,
+// or -1 alone for a selector without expressions, so that it still gets a row
+func selectorExpressionIndexes(curselector *container.Container) []int {
+	expressionlist, err := curselector.S("expressions").Children()
+	if err != nil || len(expressionlist) == 0 {
+		return []int{-1}
+	}
+
+	indexes := make([]int, len(expressionlist))
+	for i := range expressionlist {
+		indexes[i] = i
+	}
+	return indexes
+}
+
+// hasSelectorRow reports whether the selector has a row for the expression index
+func hasSelectorRow(curselector *container.Container, index int) bool {
+	for _, i := range selectorExpressionIndexes(curselector) {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorExpressionAt returns the expression at index of a selector, or nil
+func selectorExpressionAt(curselector *container.Container, index int) *container.Container {
+	expressionlist, err := curselector.S("expressions").Children()
+	if err != nil || index < 0 || index >= len(expressionlist) {
+		return nil
+	}
+	return expressionlist[index]
+}
+
+// isNotFoundError lets get calls return no row for an object that does not exist
+func isNotFoundError(err error) bool {
+	return client.IsNotFound(err)
+}
+
+// containsString reports whether list holds value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package ndo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCompositeId(t *testing.T) {
+	cases := []struct {
+		name    string
+		id      string
+		keys    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "template",
+			id:   "5c4d/template/Template1",
+			keys: []string{"template"},
+			want: []string{"5c4d", "Template1"},
+		},
+		{
+			name: "nested",
+			id:   "5c4d/template/Template1/bd/bd1/subnet/10.0.0.1/24",
+			keys: []string{"template", "bd", "subnet"},
+			want: []string{"5c4d", "Template1", "bd1", "10.0.0.1/24"},
+		},
+		{
+			name: "last value keeps its slashes",
+			id:   "5c4d/site/1/template/Template1/anp/app/epg/web/staticPort/topology/pod-1/paths-101/pathep-[eth1/1]",
+			keys: []string{"site", "template", "anp", "epg", "staticPort"},
+			want: []string{"5c4d", "1", "Template1", "app", "web", "topology/pod-1/paths-101/pathep-[eth1/1]"},
+		},
+		{
+			name: "empty value",
+			id:   "5c4d/template/Template1/filter/http/entry/",
+			keys: []string{"template", "filter", "entry"},
+			want: []string{"5c4d", "Template1", "http", ""},
+		},
+		{name: "empty", id: "", keys: []string{"template"}, wantErr: true},
+		{name: "schema only", id: "5c4d", keys: []string{"template"}, wantErr: true},
+		{name: "missing value", id: "5c4d/template", keys: []string{"template"}, wantErr: true},
+		{name: "missing level", id: "5c4d/template/Template1", keys: []string{"template", "bd"}, wantErr: true},
+		{name: "empty schema id", id: "/template/Template1", keys: []string{"template"}, wantErr: true},
+		{name: "wrong key", id: "5c4d/template/Template1/vrf/bd1", keys: []string{"template", "bd"}, wantErr: true},
+		{name: "keys out of order", id: "5c4d/bd/bd1/template/Template1", keys: []string{"template", "bd"}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseCompositeId(tc.id, tc.keys...)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseCompositeId(%q) = %q, want error", tc.id, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCompositeId(%q) returned error: %v", tc.id, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("parseCompositeId(%q) = %q, want %q", tc.id, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectorId(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	cases := []struct {
+		name      string
+		value     string
+		wantName  string
+		wantIndex *int
+		wantErr   bool
+	}{
+		{name: "selector without expressions", value: "all-web", wantName: "all-web"},
+		{name: "first expression", value: "all-web/expression/0", wantName: "all-web", wantIndex: intPtr(0)},
+		{name: "later expression", value: "all-web/expression/12", wantName: "all-web", wantIndex: intPtr(12)},
+		{name: "empty name", value: "", wantName: ""},
+		{name: "negative index", value: "all-web/expression/-1", wantErr: true},
+		{name: "index not a number", value: "all-web/expression/first", wantErr: true},
+		{name: "empty index", value: "all-web/expression/", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, index, err := parseSelectorId(tc.value)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseSelectorId(%q) = (%q, %d), want error", tc.value, name, index)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSelectorId(%q) returned error: %v", tc.value, err)
+			}
+
+			wantIndex := -1
+			if tc.wantIndex != nil {
+				wantIndex = *tc.wantIndex
+			}
+			if name != tc.wantName || index != wantIndex {
+				t.Errorf("parseSelectorId(%q) = (%q, %d), want (%q, %d)", tc.value, name, index, tc.wantName, wantIndex)
+			}
+
+			// the id built for the row must parse back to the same selector
+			if got := selectorSuffix(tc.wantName, tc.wantIndex); got != tc.value {
+				t.Errorf("selectorSuffix(%q, %v) = %q, want %q", tc.wantName, wantIndex, got, tc.value)
+			}
+		})
+	}
+
+	// the whole Get id, with the selector part as the last value of the composite id
+	index := 1
+	id := "5c4d/template/Template1/anp/app/epg/web/selector/" + selectorSuffix("all-web", &index)
+	idParts, err := parseCompositeId(id, "template", "anp", "epg", "selector")
+	if err != nil {
+		t.Fatalf("parseCompositeId(%q) returned error: %v", id, err)
+	}
+	name, gotIndex, err := parseSelectorId(idParts[4])
+	if err != nil || name != "all-web" || gotIndex != index {
+		t.Errorf("parseSelectorId(%q) = (%q, %d, %v), want (%q, %d, nil)", idParts[4], name, gotIndex, err, "all-web", index)
+	}
+}
+
+func TestSubnetCidr(t *testing.T) {
+	cases := []struct {
+		ip   string
+		want string
+	}{
+		{ip: "10.0.0.1/24", want: "10.0.0.0/24"},
+		{ip: "10.0.0.0/24", want: "10.0.0.0/24"},
+		{ip: "192.168.10.254/30", want: "192.168.10.252/30"},
+		{ip: "2001:db8::1/64", want: "2001:db8::/64"},
+		{ip: "10.0.0.1", want: ""},
+		{ip: "", want: ""},
+	}
+
+	for _, tc := range cases {
+		if got := subnetCidr(tc.ip); got != tc.want {
+			t.Errorf("subnetCidr(%q) = %q, want %q", tc.ip, got, tc.want)
+		}
+	}
+}
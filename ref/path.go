@@ -0,0 +1,116 @@
+package ref
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Path is a parsed ACI path DN as used by static ports, one of
+//
+//	topology/pod-1/paths-101/pathep-[eth1/1]
+//	topology/pod-1/paths-101/pathep-[eth1/1/2]                          (breakout port)
+//	topology/pod-1/protpaths-101-102/pathep-[vpc_policy_group]          (vPC)
+//	topology/pod-1/paths-101/extpaths-111/pathep-[eth1/1]               (FEX port)
+//	topology/pod-1/protpaths-101-102/extprotpaths-111-112/pathep-[vpc]  (vPC over FEX pair)
+type Path struct {
+	DN string
+	// Pod as it appears in the DN, e.g. pod-1
+	Pod string
+	// Leaf is the node part of the DN, e.g. 101 or 101-102 for a vPC
+	Leaf  string
+	LeafA string
+	// LeafB is only set for vPC paths
+	LeafB string
+	// FexId is only set for paths through a FEX, e.g. 111 or 111-112
+	FexId string
+	// Interface is the name between the brackets of pathep-[...], e.g. eth1/1 or
+	// the policy group name of a port channel or vPC
+	Interface string
+	// Protected is set for vPC (protpaths) paths
+	Protected bool
+}
+
+// ParsePath parses an ACI path DN
+func ParsePath(dn string) (*Path, error) {
+	start := strings.Index(dn, "/pathep-[")
+	if start < 0 || !strings.HasSuffix(dn, "]") {
+		return nil, fmt.Errorf("Invalid path %q: expected .../pathep-[{interface}]", dn)
+	}
+
+	path := &Path{
+		DN:        dn,
+		Interface: dn[start+len("/pathep-[") : len(dn)-1],
+	}
+	if path.Interface == "" {
+		return nil, fmt.Errorf("Invalid path %q: empty interface", dn)
+	}
+
+	parts := strings.Split(dn[:start], "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] != "topology" || !strings.HasPrefix(parts[1], "pod-") {
+		return nil, fmt.Errorf("Invalid path %q: expected topology/pod-{id}/...", dn)
+	}
+	path.Pod = parts[1]
+
+	switch {
+	case strings.HasPrefix(parts[2], "protpaths-"):
+		path.Protected = true
+		path.Leaf = strings.TrimPrefix(parts[2], "protpaths-")
+		nodes := strings.Split(path.Leaf, "-")
+		if len(nodes) != 2 || nodes[0] == "" || nodes[1] == "" {
+			return nil, fmt.Errorf("Invalid path %q: expected protpaths-{leaf}-{leaf}", dn)
+		}
+		path.LeafA = nodes[0]
+		path.LeafB = nodes[1]
+	case strings.HasPrefix(parts[2], "paths-"):
+		path.Leaf = strings.TrimPrefix(parts[2], "paths-")
+		path.LeafA = path.Leaf
+	default:
+		return nil, fmt.Errorf("Invalid path %q: expected paths-{leaf} or protpaths-{leaf}-{leaf}", dn)
+	}
+	if path.Leaf == "" {
+		return nil, fmt.Errorf("Invalid path %q: empty leaf", dn)
+	}
+
+	if len(parts) == 4 {
+		switch {
+		case strings.HasPrefix(parts[3], "extprotpaths-"):
+			path.FexId = strings.TrimPrefix(parts[3], "extprotpaths-")
+		case strings.HasPrefix(parts[3], "extpaths-"):
+			path.FexId = strings.TrimPrefix(parts[3], "extpaths-")
+		default:
+			return nil, fmt.Errorf("Invalid path %q: expected extpaths-{fex} or extprotpaths-{fex}-{fex}", dn)
+		}
+		if path.FexId == "" {
+			return nil, fmt.Errorf("Invalid path %q: empty fex id", dn)
+		}
+	}
+
+	return path, nil
+}
+
+// Node is a parsed ACI node DN as used by static leafs, e.g. topology/pod-1/node-101
+type Node struct {
+	DN string
+	// Pod as it appears in the DN, e.g. pod-1
+	Pod string
+	// Leaf is the node id, e.g. 101
+	Leaf string
+}
+
+// ParseNode parses an ACI node DN
+func ParseNode(dn string) (*Node, error) {
+	parts := strings.Split(dn, "/")
+	if len(parts) != 3 || parts[0] != "topology" || !strings.HasPrefix(parts[1], "pod-") || !strings.HasPrefix(parts[2], "node-") {
+		return nil, fmt.Errorf("Invalid node %q: expected topology/pod-{id}/node-{id}", dn)
+	}
+
+	node := &Node{
+		DN:   dn,
+		Pod:  parts[1],
+		Leaf: strings.TrimPrefix(parts[2], "node-"),
+	}
+	if node.Leaf == "" {
+		return nil, fmt.Errorf("Invalid node %q: empty node id", dn)
+	}
+	return node, nil
+}
@@ -0,0 +1,117 @@
+package ref
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	cases := []struct {
+		name    string
+		dn      string
+		want    *Path
+		wantErr bool
+	}{
+		{
+			name: "port",
+			dn:   "topology/pod-1/paths-101/pathep-[eth1/1]",
+			want: &Path{DN: "topology/pod-1/paths-101/pathep-[eth1/1]", Pod: "pod-1", Leaf: "101", LeafA: "101", Interface: "eth1/1"},
+		},
+		{
+			name: "breakout port",
+			dn:   "topology/pod-1/paths-101/pathep-[eth1/1/2]",
+			want: &Path{DN: "topology/pod-1/paths-101/pathep-[eth1/1/2]", Pod: "pod-1", Leaf: "101", LeafA: "101", Interface: "eth1/1/2"},
+		},
+		{
+			name: "port channel",
+			dn:   "topology/pod-2/paths-201/pathep-[pc_policy_group]",
+			want: &Path{DN: "topology/pod-2/paths-201/pathep-[pc_policy_group]", Pod: "pod-2", Leaf: "201", LeafA: "201", Interface: "pc_policy_group"},
+		},
+		{
+			name: "vpc",
+			dn:   "topology/pod-1/protpaths-101-102/pathep-[vpc_policy_group]",
+			want: &Path{DN: "topology/pod-1/protpaths-101-102/pathep-[vpc_policy_group]", Pod: "pod-1", Leaf: "101-102", LeafA: "101", LeafB: "102", Interface: "vpc_policy_group", Protected: true},
+		},
+		{
+			name: "fex port",
+			dn:   "topology/pod-1/paths-101/extpaths-111/pathep-[eth1/1]",
+			want: &Path{DN: "topology/pod-1/paths-101/extpaths-111/pathep-[eth1/1]", Pod: "pod-1", Leaf: "101", LeafA: "101", FexId: "111", Interface: "eth1/1"},
+		},
+		{
+			name: "vpc over fex pair",
+			dn:   "topology/pod-1/protpaths-101-102/extprotpaths-111-112/pathep-[vpc]",
+			want: &Path{DN: "topology/pod-1/protpaths-101-102/extprotpaths-111-112/pathep-[vpc]", Pod: "pod-1", Leaf: "101-102", LeafA: "101", LeafB: "102", FexId: "111-112", Interface: "vpc", Protected: true},
+		},
+		{name: "empty", dn: "", wantErr: true},
+		{name: "no pathep", dn: "topology/pod-1/paths-101", wantErr: true},
+		{name: "no closing bracket", dn: "topology/pod-1/paths-101/pathep-[eth1/1", wantErr: true},
+		{name: "empty interface", dn: "topology/pod-1/paths-101/pathep-[]", wantErr: true},
+		{name: "missing pod prefix", dn: "topology/1/paths-101/pathep-[eth1/1]", wantErr: true},
+		{name: "missing topology", dn: "pod-1/paths-101/pathep-[eth1/1]", wantErr: true},
+		{name: "too many segments", dn: "topology/pod-1/paths-101/extpaths-111/extra/pathep-[eth1/1]", wantErr: true},
+		{name: "unknown path kind", dn: "topology/pod-1/nodes-101/pathep-[eth1/1]", wantErr: true},
+		{name: "empty leaf", dn: "topology/pod-1/paths-/pathep-[eth1/1]", wantErr: true},
+		{name: "vpc with one leaf", dn: "topology/pod-1/protpaths-101/pathep-[vpc]", wantErr: true},
+		{name: "vpc with empty leaf", dn: "topology/pod-1/protpaths-101-/pathep-[vpc]", wantErr: true},
+		{name: "vpc with three leafs", dn: "topology/pod-1/protpaths-101-102-103/pathep-[vpc]", wantErr: true},
+		{name: "unknown fex kind", dn: "topology/pod-1/paths-101/fex-111/pathep-[eth1/1]", wantErr: true},
+		{name: "empty fex id", dn: "topology/pod-1/paths-101/extpaths-/pathep-[eth1/1]", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePath(tc.dn)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ParsePath(%q) = %+v, want error", tc.dn, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePath(%q) returned error: %v", tc.dn, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ParsePath(%q) = %+v, want %+v", tc.dn, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseNode(t *testing.T) {
+	cases := []struct {
+		name    string
+		dn      string
+		want    *Node
+		wantErr bool
+	}{
+		{
+			name: "node",
+			dn:   "topology/pod-1/node-101",
+			want: &Node{DN: "topology/pod-1/node-101", Pod: "pod-1", Leaf: "101"},
+		},
+		{name: "empty", dn: "", wantErr: true},
+		{name: "empty node id", dn: "topology/pod-1/node-", wantErr: true},
+		{name: "missing pod prefix", dn: "topology/1/node-101", wantErr: true},
+		{name: "missing node prefix", dn: "topology/pod-1/101", wantErr: true},
+		{name: "path dn", dn: "topology/pod-1/paths-101/pathep-[eth1/1]", wantErr: true},
+		{name: "trailing slash", dn: "topology/pod-1/node-101/", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseNode(tc.dn)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ParseNode(%q) = %+v, want error", tc.dn, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseNode(%q) returned error: %v", tc.dn, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ParseNode(%q) = %+v, want %+v", tc.dn, got, tc.want)
+			}
+		})
+	}
+}
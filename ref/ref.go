@@ -0,0 +1,93 @@
+// Package ref parses the references between objects found in NDO schema documents,
+// e.g. /schemas/{id}/templates/{t}/vrfs/{v}, and the ACI path DNs used by static ports.
+package ref
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Segment is one level of an object reference, e.g. kind "anps" and name "app"
+type Segment struct {
+	Kind string
+	Name string
+}
+
+// ObjectRef is a parsed reference to an object defined in a schema template
+type ObjectRef struct {
+	SchemaId     string
+	TemplateName string
+	// Objects holds the path below the template, outermost first, ending with the
+	// referenced object itself
+	Objects []Segment
+}
+
+// Parse parses a reference of the form /schemas/{id}/templates/{t}/{kind}/{name}[/{kind}/{name}...].
+// The leading slash is optional.
+func Parse(ref string) (*ObjectRef, error) {
+	parts := strings.Split(strings.TrimPrefix(ref, "/"), "/")
+	if len(parts) < 6 || len(parts)%2 != 0 {
+		return nil, fmt.Errorf("Invalid reference %q: expected /schemas/{id}/templates/{name}/{kind}/{name}", ref)
+	}
+	if parts[0] != "schemas" || parts[2] != "templates" {
+		return nil, fmt.Errorf("Invalid reference %q: expected /schemas/{id}/templates/{name} prefix", ref)
+	}
+
+	objRef := &ObjectRef{
+		SchemaId:     parts[1],
+		TemplateName: parts[3],
+	}
+	for i := 4; i < len(parts); i += 2 {
+		objRef.Objects = append(objRef.Objects, Segment{Kind: parts[i], Name: parts[i+1]})
+	}
+
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("Invalid reference %q: empty segment", ref)
+		}
+	}
+	return objRef, nil
+}
+
+// ParseKind parses a reference and checks that it points to an object of the given
+// kind, e.g. "vrfs" or "epgs"
+func ParseKind(ref string, kind string) (*ObjectRef, error) {
+	objRef, err := Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+	if objRef.Kind() != kind {
+		return nil, fmt.Errorf("Invalid reference %q: expected a reference to %s", ref, kind)
+	}
+	return objRef, nil
+}
+
+// Kind returns the kind of the referenced object
+func (r *ObjectRef) Kind() string {
+	return r.Objects[len(r.Objects)-1].Kind
+}
+
+// Name returns the name of the referenced object
+func (r *ObjectRef) Name() string {
+	return r.Objects[len(r.Objects)-1].Name
+}
+
+// NameOf returns the name of the object of the given kind along the reference,
+// e.g. NameOf("anps") on an EPG reference, or "" if there is none
+func (r *ObjectRef) NameOf(kind string) string {
+	for _, segment := range r.Objects {
+		if segment.Kind == kind {
+			return segment.Name
+		}
+	}
+	return ""
+}
+
+// String returns the reference in its canonical form
+func (r *ObjectRef) String() string {
+	ref := "/schemas/" + r.SchemaId + "/templates/" + r.TemplateName
+	for _, segment := range r.Objects {
+		ref += "/" + segment.Kind + "/" + segment.Name
+	}
+	return ref
+}
@@ -0,0 +1,110 @@
+package ref
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name    string
+		ref     string
+		want    *ObjectRef
+		wantErr bool
+	}{
+		{
+			name: "vrf",
+			ref:  "/schemas/5c4d/templates/Template1/vrfs/vrf1",
+			want: &ObjectRef{SchemaId: "5c4d", TemplateName: "Template1", Objects: []Segment{{"vrfs", "vrf1"}}},
+		},
+		{
+			name: "without leading slash",
+			ref:  "schemas/5c4d/templates/Template1/bds/bd1",
+			want: &ObjectRef{SchemaId: "5c4d", TemplateName: "Template1", Objects: []Segment{{"bds", "bd1"}}},
+		},
+		{
+			name: "nested epg",
+			ref:  "/schemas/5c4d/templates/Template1/anps/app/epgs/web",
+			want: &ObjectRef{SchemaId: "5c4d", TemplateName: "Template1", Objects: []Segment{{"anps", "app"}, {"epgs", "web"}}},
+		},
+		{name: "empty", ref: "", wantErr: true},
+		{name: "template only", ref: "/schemas/5c4d/templates/Template1", wantErr: true},
+		{name: "odd segment count", ref: "/schemas/5c4d/templates/Template1/vrfs", wantErr: true},
+		{name: "odd nested segment count", ref: "/schemas/5c4d/templates/Template1/anps/app/epgs", wantErr: true},
+		{name: "wrong schemas segment", ref: "/schema/5c4d/templates/Template1/vrfs/vrf1", wantErr: true},
+		{name: "wrong templates segment", ref: "/schemas/5c4d/template/Template1/vrfs/vrf1", wantErr: true},
+		{name: "empty schema id", ref: "/schemas//templates/Template1/vrfs/vrf1", wantErr: true},
+		{name: "empty object name", ref: "/schemas/5c4d/templates/Template1/vrfs/", wantErr: true},
+		{name: "trailing slash", ref: "/schemas/5c4d/templates/Template1/vrfs/vrf1/", wantErr: true},
+		{name: "double leading slash", ref: "//schemas/5c4d/templates/Template1/vrfs/vrf1", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(tc.ref)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want error", tc.ref, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tc.ref, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tc.ref, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	cases := []struct {
+		name     string
+		ref      string
+		kind     string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "matching kind", ref: "/schemas/5c4d/templates/Template1/filters/http", kind: "filters", wantName: "http"},
+		{name: "matching nested kind", ref: "/schemas/5c4d/templates/Template1/anps/app/epgs/web", kind: "epgs", wantName: "web"},
+		{name: "outer kind does not match", ref: "/schemas/5c4d/templates/Template1/anps/app/epgs/web", kind: "anps", wantErr: true},
+		{name: "other kind", ref: "/schemas/5c4d/templates/Template1/vrfs/vrf1", kind: "bds", wantErr: true},
+		{name: "invalid reference", ref: "/schemas/5c4d", kind: "vrfs", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseKind(tc.ref, tc.kind)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ParseKind(%q, %q) = %+v, want error", tc.ref, tc.kind, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseKind(%q, %q) returned error: %v", tc.ref, tc.kind, err)
+			}
+			if got.Kind() != tc.kind || got.Name() != tc.wantName {
+				t.Errorf("ParseKind(%q, %q) = %s %s, want %s %s", tc.ref, tc.kind, got.Kind(), got.Name(), tc.kind, tc.wantName)
+			}
+		})
+	}
+}
+
+func TestObjectRefAccessors(t *testing.T) {
+	objRef, err := Parse("schemas/5c4d/templates/Template1/anps/app/epgs/web")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	if got := objRef.NameOf("anps"); got != "app" {
+		t.Errorf("NameOf(anps) = %q, want %q", got, "app")
+	}
+	if got := objRef.NameOf("bds"); got != "" {
+		t.Errorf("NameOf(bds) = %q, want empty", got)
+	}
+	if got, want := objRef.String(), "/schemas/5c4d/templates/Template1/anps/app/epgs/web"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}